@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+)
+
+type acmInterface interface {
+	ListCertificatesPages(*acm.ListCertificatesInput, func(*acm.ListCertificatesOutput, bool) bool) error
+}
+
+// FindCertificate finds an issued ACM certificate for a domain, an exact match is preferred
+// over a wildcard certificate for the parent domain
+func FindCertificate(svc acmInterface, domain string) (string, error) {
+	var exact, wildcard string
+
+	parent := ""
+	if idx := strings.Index(domain, "."); idx != -1 {
+		parent = "*" + domain[idx:]
+	}
+
+	err := svc.ListCertificatesPages(&acm.ListCertificatesInput{
+		CertificateStatuses: []*string{aws.String(acm.CertificateStatusIssued)},
+	}, func(page *acm.ListCertificatesOutput, lastPage bool) bool {
+		for _, cert := range page.CertificateSummaryList {
+			switch aws.StringValue(cert.DomainName) {
+			case domain:
+				exact = *cert.CertificateArn
+			case parent:
+				wildcard = *cert.CertificateArn
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if exact != "" {
+		return exact, nil
+	} else if wildcard != "" {
+		return wildcard, nil
+	}
+
+	return "", fmt.Errorf("No issued ACM certificate found for %s", domain)
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+type autoscalingInterface interface {
+	DescribeAutoScalingGroups(*autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error)
+	SetInstanceProtection(*autoscaling.SetInstanceProtectionInput) (*autoscaling.SetInstanceProtectionOutput, error)
+	TerminateInstanceInAutoScalingGroup(*autoscaling.TerminateInstanceInAutoScalingGroupInput) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error)
+	SetDesiredCapacity(*autoscaling.SetDesiredCapacityInput) (*autoscaling.SetDesiredCapacityOutput, error)
+	SuspendProcesses(*autoscaling.ScalingProcessQuery) (*autoscaling.SuspendProcessesOutput, error)
+	ResumeProcesses(*autoscaling.ScalingProcessQuery) (*autoscaling.ResumeProcessesOutput, error)
+}
+
+// the processes that change an auto scaling group's size on their own, AlarmNotification
+// covers the scaling policies the capacity provider's managed scaling creates
+var automaticScalingProcesses = []string{"AlarmNotification", "AZRebalance", "ScheduledActions"}
+
+// DescribeAutoScalingGroup returns an auto scaling group by name
+func DescribeAutoScalingGroup(svc autoscalingInterface, group string) (*autoscaling.Group, error) {
+	resp, err := svc.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(group)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("No auto scaling group named %s", group)
+	}
+
+	return resp.AutoScalingGroups[0], nil
+}
+
+// DescribeAutoScalingGroups returns auto scaling groups by name, groups that don't exist are left out
+func DescribeAutoScalingGroups(svc autoscalingInterface, groups []string) (map[string]*autoscaling.Group, error) {
+	result := map[string]*autoscaling.Group{}
+
+	// DescribeAutoScalingGroups accepts at most 50 groups per call
+	for start := 0; start < len(groups); start += 50 {
+		end := start + 50
+		if end > len(groups) {
+			end = len(groups)
+		}
+
+		resp, err := svc.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: aws.StringSlice(groups[start:end]),
+			MaxRecords:            aws.Int64(50),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range resp.AutoScalingGroups {
+			result[*group.AutoScalingGroupName] = group
+		}
+	}
+
+	return result, nil
+}
+
+// SetDesiredCapacity changes the number of instances an auto scaling group runs
+func SetDesiredCapacity(svc autoscalingInterface, group string, capacity int64) error {
+	_, err := svc.SetDesiredCapacity(&autoscaling.SetDesiredCapacityInput{
+		AutoScalingGroupName: aws.String(group),
+		DesiredCapacity:      aws.Int64(capacity),
+	})
+	return err
+}
+
+// SuspendScaling stops an auto scaling group from changing its size on its own, so that
+// capacity can be managed by hand
+func SuspendScaling(svc autoscalingInterface, group string) error {
+	_, err := svc.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(group),
+		ScalingProcesses:     aws.StringSlice(automaticScalingProcesses),
+	})
+	return err
+}
+
+// ResumeScaling undoes SuspendScaling
+func ResumeScaling(svc autoscalingInterface, group string) error {
+	_, err := svc.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: aws.String(group),
+		ScalingProcesses:     aws.StringSlice(automaticScalingProcesses),
+	})
+	return err
+}
+
+// UnprotectInstances removes the scale in protection the capacity provider puts on instances,
+// otherwise the instances of a deleted auto scaling group are never terminated
+func UnprotectInstances(svc autoscalingInterface, group string) error {
+	resp, err := svc.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(group)},
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := []*string{}
+	for _, g := range resp.AutoScalingGroups {
+		for _, instance := range g.Instances {
+			if aws.BoolValue(instance.ProtectedFromScaleIn) {
+				ids = append(ids, instance.InstanceId)
+			}
+		}
+	}
+
+	// SetInstanceProtection accepts at most 50 instances per call
+	for start := 0; start < len(ids); start += 50 {
+		end := start + 50
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		_, err = svc.SetInstanceProtection(&autoscaling.SetInstanceProtectionInput{
+			AutoScalingGroupName: aws.String(group),
+			InstanceIds:          ids[start:end],
+			ProtectedFromScaleIn: aws.Bool(false),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TerminateInstance terminates an instance in an auto scaling group, which launches a
+// replacement unless the desired capacity is decremented
+func TerminateInstance(svc autoscalingInterface, instanceID string, decrementCapacity bool) error {
+	_, err := svc.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(decrementCapacity),
+	})
+	return err
+}
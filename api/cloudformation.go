@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/fatih/color"
 )
@@ -16,6 +17,8 @@ type cfnInterface interface {
 	DescribeStackEventsPages(*cloudformation.DescribeStackEventsInput, func(*cloudformation.DescribeStackEventsOutput, bool) bool) error
 	DescribeStacks(*cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error)
 	CreateStack(*cloudformation.CreateStackInput) (*cloudformation.CreateStackOutput, error)
+	UpdateStack(*cloudformation.UpdateStackInput) (*cloudformation.UpdateStackOutput, error)
+	GetTemplateSummary(*cloudformation.GetTemplateSummaryInput) (*cloudformation.GetTemplateSummaryOutput, error)
 	DeleteStack(*cloudformation.DeleteStackInput) (*cloudformation.DeleteStackOutput, error)
 }
 
@@ -56,6 +59,15 @@ func GetStackOutputByKey(stack *cloudformation.Stack, key string) (string, bool)
 	return "", false
 }
 
+func GetStackParameterByKey(stack *cloudformation.Stack, key string) (string, bool) {
+	for _, param := range stack.Parameters {
+		if *param.ParameterKey == key && param.ParameterValue != nil {
+			return *param.ParameterValue, true
+		}
+	}
+	return "", false
+}
+
 var ErrNoStacksFound = errors.New("No matching stacks found")
 
 func FindStacksByOutputs(svc cfnInterface, match map[string]string) ([]*cloudformation.Stack, error) {
@@ -80,11 +92,33 @@ func findAllActiveStacks(svc cfnInterface) (stacks []*cloudformation.Stack, err
 				stacks = append(stacks, s)
 			}
 		}
-		return last
+		return true
 	})
 	return
 }
 
+// FindNetworkStacks returns the network stacks created for clusters, keyed by cluster name
+func FindNetworkStacks(svc cfnInterface) (map[string]*cloudformation.Stack, error) {
+	stacks, err := findAllActiveStacks(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := map[string]*cloudformation.Stack{}
+	for _, stack := range stacks {
+		if strings.HasSuffix(*stack.StackName, "-network") {
+			networks[strings.TrimSuffix(*stack.StackName, "-network")] = stack
+		}
+	}
+	return networks, nil
+}
+
+// isStackNotExist returns whether err is the error returned when describing a stack that doesn't exist
+func isStackNotExist(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "ValidationError" && strings.Contains(aerr.Message(), "does not exist")
+}
+
 func FindStacksByName(svc cfnInterface, stackName string) (stacks []*cloudformation.Stack, err error) {
 	filter := &cloudformation.DescribeStacksInput{
 		StackName: &stackName,
@@ -96,7 +130,7 @@ func FindStacksByName(svc cfnInterface, stackName string) (stacks []*cloudformat
 				stacks = append(stacks, s)
 			}
 		}
-		return last
+		return true
 	})
 	return
 }
@@ -119,6 +153,7 @@ func CreateStack(svc cfnInterface, name string, body string, ctx CreateStackCont
 		StackName: aws.String(name),
 		Capabilities: []*string{
 			aws.String("CAPABILITY_IAM"),
+			aws.String("CAPABILITY_NAMED_IAM"),
 		},
 		DisableRollback: aws.Bool(ctx.DisableRollback),
 		Parameters:      paramsSlice,
@@ -130,6 +165,83 @@ func CreateStack(svc cfnInterface, name string, body string, ctx CreateStackCont
 	return nil
 }
 
+type UpdateStackContext struct {
+	Params map[string]string
+}
+
+var ErrNoStackUpdates = errors.New("No updates are to be performed")
+
+// UpdateStack updates a stack to a new template, or keeps its current template if body is empty.
+// Parameters that aren't in ctx.Params keep their previous values, unless the new template no
+// longer declares them.
+func UpdateStack(svc cfnInterface, stack *cloudformation.Stack, body string, ctx UpdateStackContext) error {
+	summaryInput := &cloudformation.GetTemplateSummaryInput{}
+	input := &cloudformation.UpdateStackInput{
+		StackName: stack.StackId,
+		Capabilities: []*string{
+			aws.String("CAPABILITY_IAM"),
+			aws.String("CAPABILITY_NAMED_IAM"),
+		},
+	}
+
+	if body == "" {
+		summaryInput.StackName = stack.StackId
+		input.UsePreviousTemplate = aws.Bool(true)
+	} else {
+		summaryInput.TemplateBody = aws.String(body)
+		input.TemplateBody = aws.String(body)
+	}
+
+	summary, err := svc.GetTemplateSummary(summaryInput)
+	if err != nil {
+		return err
+	}
+
+	declared := map[string]bool{}
+	for _, param := range summary.Parameters {
+		declared[*param.ParameterKey] = true
+	}
+
+	paramsSlice := []*cloudformation.Parameter{}
+	for k, v := range ctx.Params {
+		paramsSlice = append(paramsSlice, &cloudformation.Parameter{
+			ParameterKey:   aws.String(k),
+			ParameterValue: aws.String(v),
+		})
+	}
+
+	for _, param := range stack.Parameters {
+		if _, exists := ctx.Params[*param.ParameterKey]; !exists && declared[*param.ParameterKey] {
+			paramsSlice = append(paramsSlice, &cloudformation.Parameter{
+				ParameterKey:     param.ParameterKey,
+				UsePreviousValue: aws.Bool(true),
+			})
+		}
+	}
+
+	input.Parameters = paramsSlice
+	_, err = svc.UpdateStack(input)
+	if err != nil && strings.Contains(err.Error(), ErrNoStackUpdates.Error()) {
+		return ErrNoStackUpdates
+	}
+	return err
+}
+
+// UpdateStackAndWait updates a stack and polls until the update finishes, only considering
+// events after the update was requested
+func UpdateStackAndWait(svc cfnInterface, stack *cloudformation.Stack, body string, ctx UpdateStackContext, f func(e *cloudformation.StackEvent)) error {
+	lastSeen, err := latestStackEventTime(svc, *stack.StackId)
+	if err != nil {
+		return err
+	}
+
+	if err = UpdateStack(svc, stack, body, ctx); err != nil {
+		return err
+	}
+
+	return pollStackEvents(svc, *stack.StackId, *stack.StackName, lastSeen, isCreateUpdateComplete, f)
+}
+
 func DeleteStack(svc cfnInterface, name string) error {
 	_, err := svc.DeleteStack(&cloudformation.DeleteStackInput{
 		StackName: &name,
@@ -163,11 +275,37 @@ func PollUntilDeleted(svc cfnInterface, stackName string, f func(e *cloudformati
 	return PollStackEventsUntil(svc, stackName, isDeleteComplete, f)
 }
 
+// DeleteStackAndWait deletes a stack and polls until the deletion finishes. Only events
+// after the delete request are considered, so a stack that previously failed to delete
+// can be retried. The stack id is used so the stack can still be described once deleted.
+func DeleteStackAndWait(svc cfnInterface, stack *cloudformation.Stack, f func(e *cloudformation.StackEvent)) error {
+	lastSeen, err := latestStackEventTime(svc, *stack.StackId)
+	if err != nil {
+		return err
+	}
+
+	if err = DeleteStack(svc, *stack.StackId); err != nil {
+		return err
+	}
+
+	return pollStackEvents(svc, *stack.StackId, *stack.StackName, lastSeen, isDeleteComplete, f)
+}
+
+func latestStackEventTime(svc cfnInterface, stackID string) (time.Time, error) {
+	events, err := allStackEvents(svc, stackID, time.Time{})
+	if err != nil || len(events) == 0 {
+		return time.Time{}, err
+	}
+	return *events[0].Timestamp, nil
+}
+
 func PollStackEventsUntil(svc cfnInterface, stackName string, terminalCondition EventChecker, f func(e *cloudformation.StackEvent)) error {
-	lastSeen := time.Time{}
+	return pollStackEvents(svc, stackName, stackName, time.Time{}, terminalCondition, f)
+}
 
+func pollStackEvents(svc cfnInterface, stackID, stackName string, lastSeen time.Time, terminalCondition EventChecker, f func(e *cloudformation.StackEvent)) error {
 	for {
-		events, err := allStackEvents(svc, stackName, lastSeen)
+		events, err := allStackEvents(svc, stackID, lastSeen)
 		if err != nil {
 			return err
 		}
@@ -229,6 +367,9 @@ func isCreateUpdateComplete(stackName string, ev *cloudformation.StackEvent) (bo
 			var err error
 			if ev.ResourceStatusReason != nil {
 				err = errors.New(*ev.ResourceStatusReason)
+			} else if strings.Contains(*ev.ResourceStatus, "ROLLBACK") {
+				// rollback events rarely have a reason, the cause is in earlier events
+				err = fmt.Errorf("Stack %s failed with %s", stackName, *ev.ResourceStatus)
 			}
 			return true, err
 		}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+type ec2Interface interface {
+	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+}
+
+// DescribeVpc returns a VPC by id
+func DescribeVpc(svc ec2Interface, vpcID string) (*ec2.Vpc, error) {
+	resp, err := svc.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String(vpcID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Vpcs) == 0 {
+		return nil, fmt.Errorf("No VPC with id %s", vpcID)
+	}
+
+	return resp.Vpcs[0], nil
+}
+
+// DescribeSubnets returns subnets by id, checking that they all belong to the VPC
+func DescribeSubnets(svc ec2Interface, vpcID string, ids []string) ([]*ec2.Subnet, error) {
+	resp, err := svc.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(ids),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subnet := range resp.Subnets {
+		if *subnet.VpcId != vpcID {
+			return nil, fmt.Errorf("Subnet %s is in %s, not %s", *subnet.SubnetId, *subnet.VpcId, vpcID)
+		}
+	}
+
+	return resp.Subnets, nil
+}
+
+// FindSubnetsByTag returns the subnets in the VPC with a tag, any value of the tag matches
+// if value is empty
+func FindSubnetsByTag(svc ec2Interface, vpcID, key, value string) ([]*ec2.Subnet, error) {
+	filters := []*ec2.Filter{
+		{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}},
+	}
+
+	if value == "" {
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag-key"), Values: []*string{aws.String(key)}})
+	} else {
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + key), Values: []*string{aws.String(value)}})
+	}
+
+	resp, err := svc.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Subnets) == 0 {
+		return nil, fmt.Errorf("No subnets in %s are tagged %s", vpcID, key)
+	}
+
+	return resp.Subnets, nil
+}
+
+// ListAvailabilityZones returns the names of the available zones in the region
+func ListAvailabilityZones(svc ec2Interface) ([]string, error) {
+	resp, err := svc.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("state"), Values: []*string{aws.String("available")}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zones := []string{}
+	for _, zone := range resp.AvailabilityZones {
+		zones = append(zones, *zone.ZoneName)
+	}
+	return zones, nil
+}
@@ -3,24 +3,57 @@ package api
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ecs"
 )
 
 const ECS_POLL_INTERVAL = 1 * time.Second
 
+const (
+	ClusterStatusActive   = "ACTIVE"
+	ClusterStatusInactive = "INACTIVE"
+)
+
+const (
+	ContainerInstanceStatusActive   = "ACTIVE"
+	ContainerInstanceStatusDraining = "DRAINING"
+	ContainerInstanceStatusInactive = "INACTIVE"
+)
+
+const (
+	PlacementStrategyTypeSpread  = "spread"
+	PlacementStrategyTypeBinpack = "binpack"
+	PlacementStrategyTypeRandom  = "random"
+
+	PlacementConstraintTypeDistinctInstance = "distinctInstance"
+	PlacementConstraintTypeMemberOf         = "memberOf"
+)
+
+// tasks started by a service have a startedBy of ecs-svc/ followed by the deployment id
+const serviceTaskStartedByPrefix = "ecs-svc/"
+
 type ecsInterface interface {
 	DescribeServices(*ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error)
 	CreateCluster(*ecs.CreateClusterInput) (*ecs.CreateClusterOutput, error)
+	DeleteCluster(*ecs.DeleteClusterInput) (*ecs.DeleteClusterOutput, error)
+	DescribeClusters(*ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error)
 	RegisterTaskDefinition(*ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error)
 	UpdateService(*ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error)
-	RunTask(input *ecs.RunTaskInput) (*ecs.RunTaskOutput, error)
 	DescribeTasks(input *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error)
+	ListTasksPages(input *ecs.ListTasksInput, fn func(p *ecs.ListTasksOutput, lastPage bool) (shouldContinue bool)) error
 	WaitUntilTasksStopped(input *ecs.DescribeTasksInput) error
+	ListTaskDefinitionsPages(input *ecs.ListTaskDefinitionsInput, fn func(p *ecs.ListTaskDefinitionsOutput, lastPage bool) (shouldContinue bool)) error
+	DeregisterTaskDefinition(input *ecs.DeregisterTaskDefinitionInput) (*ecs.DeregisterTaskDefinitionOutput, error)
+	ListContainerInstances(input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error)
+	ListContainerInstancesPages(input *ecs.ListContainerInstancesInput, fn func(p *ecs.ListContainerInstancesOutput, lastPage bool) (shouldContinue bool)) error
+	DescribeContainerInstances(input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error)
+	NewRequest(operation *request.Operation, params, data interface{}) *request.Request
 }
 
 func UpdateContainerImages(defs []*ecs.ContainerDefinition, images map[string]string) error {
@@ -67,6 +100,24 @@ func (d *dockerImageName) String() string {
 	return fmt.Sprintf("%s:%s", d.Image, d.Tag)
 }
 
+// ClusterExists returns whether an ECS cluster exists and hasn't been deleted
+func ClusterExists(svc ecsInterface, cluster string) (bool, error) {
+	resp, err := svc.DescribeClusters(&ecs.DescribeClustersInput{
+		Clusters: []*string{aws.String(cluster)},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, c := range resp.Clusters {
+		if *c.Status != ClusterStatusInactive {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func getService(svc ecsInterface, cluster, service string) (*ecs.Service, error) {
 	resp, err := svc.DescribeServices(&ecs.DescribeServicesInput{
 		Services: []*string{aws.String(service)},
@@ -87,6 +138,376 @@ func getService(svc ecsInterface, cluster, service string) (*ecs.Service, error)
 	return resp.Services[0], nil
 }
 
+// DescribeServices returns the named services in a cluster, keyed by service name and ARN
+func DescribeServices(svc ecsInterface, cluster string, services []string) (map[string]*ecs.Service, error) {
+	result := map[string]*ecs.Service{}
+
+	// DescribeServices accepts at most 10 services per call
+	for start := 0; start < len(services); start += 10 {
+		end := start + 10
+		if end > len(services) {
+			end = len(services)
+		}
+
+		resp, err := svc.DescribeServices(&ecs.DescribeServicesInput{
+			Services: aws.StringSlice(services[start:end]),
+			Cluster:  aws.String(cluster),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// services can be identified by either name or ARN, so both are keys
+		for _, service := range resp.Services {
+			result[*service.ServiceName] = service
+			result[*service.ServiceArn] = service
+		}
+	}
+
+	return result, nil
+}
+
+func ListContainerInstances(svc ecsInterface, cluster string) ([]*ecs.ContainerInstance, error) {
+	arns := []*string{}
+
+	err := svc.ListContainerInstancesPages(&ecs.ListContainerInstancesInput{
+		Cluster: aws.String(cluster),
+	}, func(page *ecs.ListContainerInstancesOutput, lastPage bool) bool {
+		arns = append(arns, page.ContainerInstanceArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*ecs.ContainerInstance{}
+
+	// DescribeContainerInstances accepts at most 100 instances per call
+	for start := 0; start < len(arns); start += 100 {
+		end := start + 100
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		resp, err := svc.DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{
+			Cluster:            aws.String(cluster),
+			ContainerInstances: arns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, resp.ContainerInstances...)
+	}
+
+	return instances, nil
+}
+
+// FindContainerInstance finds a container instance by its EC2 instance id, ARN or id
+func FindContainerInstance(svc ecsInterface, cluster, id string) (*ecs.ContainerInstance, error) {
+	arns := []*string{aws.String(id)}
+
+	if strings.HasPrefix(id, "i-") {
+		resp, err := svc.ListContainerInstances(&ecs.ListContainerInstancesInput{
+			Cluster: aws.String(cluster),
+			Filter:  aws.String("ec2InstanceId == " + id),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.ContainerInstanceArns) == 0 {
+			return nil, fmt.Errorf("Instance %s isn't registered with cluster %s", id, cluster)
+		}
+		arns = resp.ContainerInstanceArns
+	}
+
+	resp, err := svc.DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{
+		Cluster:            aws.String(cluster),
+		ContainerInstances: arns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Failures) > 0 {
+		return nil, fmt.Errorf("Container instance %s: %s", id, *resp.Failures[0].Reason)
+	}
+
+	return resp.ContainerInstances[0], nil
+}
+
+// the vendored aws-sdk-go predates container instance draining, so the request and response
+// of UpdateContainerInstancesState are defined here
+type updateContainerInstancesStateInput struct {
+	_ struct{} `type:"structure"`
+
+	Cluster            *string   `locationName:"cluster" type:"string"`
+	ContainerInstances []*string `locationName:"containerInstances" type:"list" required:"true"`
+	Status             *string   `locationName:"status" type:"string" required:"true"`
+}
+
+type updateContainerInstancesStateOutput struct {
+	_ struct{} `type:"structure"`
+
+	Failures []*ecs.Failure `locationName:"failures" type:"list"`
+}
+
+// DrainContainerInstance sets a container instance to DRAINING, so service tasks are replaced
+// on other instances and no new tasks are placed on it
+func DrainContainerInstance(svc ecsInterface, cluster, arn string) error {
+	output := &updateContainerInstancesStateOutput{}
+
+	req := svc.NewRequest(&request.Operation{
+		Name:       "UpdateContainerInstancesState",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, &updateContainerInstancesStateInput{
+		Cluster:            aws.String(cluster),
+		ContainerInstances: []*string{aws.String(arn)},
+		Status:             aws.String(ContainerInstanceStatusDraining),
+	}, output)
+
+	if err := req.Send(); err != nil {
+		return err
+	}
+
+	if len(output.Failures) > 0 {
+		return fmt.Errorf("Failed to drain %s: %s", arn, *output.Failures[0].Reason)
+	}
+
+	return nil
+}
+
+// the vendored aws-sdk-go predates task placement, so RunTask is sent with the
+// placement fields defined here
+type PlacementStrategy struct {
+	_ struct{} `type:"structure"`
+
+	Type  *string `locationName:"type" type:"string"`
+	Field *string `locationName:"field" type:"string"`
+}
+
+type PlacementConstraint struct {
+	_ struct{} `type:"structure"`
+
+	Type       *string `locationName:"type" type:"string"`
+	Expression *string `locationName:"expression" type:"string"`
+}
+
+type runTaskInput struct {
+	_ struct{} `type:"structure"`
+
+	Cluster              *string                `locationName:"cluster" type:"string"`
+	Count                *int64                 `locationName:"count" type:"integer"`
+	Overrides            *ecs.TaskOverride      `locationName:"overrides" type:"structure"`
+	StartedBy            *string                `locationName:"startedBy" type:"string"`
+	TaskDefinition       *string                `locationName:"taskDefinition" type:"string" required:"true"`
+	PlacementStrategy    []*PlacementStrategy   `locationName:"placementStrategy" type:"list"`
+	PlacementConstraints []*PlacementConstraint `locationName:"placementConstraints" type:"list"`
+}
+
+// RunTask starts tasks placed with the given strategies and constraints
+func RunTask(svc ecsInterface, input *ecs.RunTaskInput, strategies []*PlacementStrategy, constraints []*PlacementConstraint) (*ecs.RunTaskOutput, error) {
+	output := &ecs.RunTaskOutput{}
+
+	req := svc.NewRequest(&request.Operation{
+		Name:       "RunTask",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, &runTaskInput{
+		Cluster:              input.Cluster,
+		Count:                input.Count,
+		Overrides:            input.Overrides,
+		StartedBy:            input.StartedBy,
+		TaskDefinition:       input.TaskDefinition,
+		PlacementStrategy:    strategies,
+		PlacementConstraints: constraints,
+	}, output)
+
+	if err := req.Send(); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// CountServiceTasks returns the number of tasks on a container instance that were started by
+// services. Draining doesn't stop tasks started with RunTask, so they aren't counted.
+func CountServiceTasks(svc ecsInterface, cluster, arn string) (int, error) {
+	arns := []*string{}
+	err := svc.ListTasksPages(&ecs.ListTasksInput{
+		Cluster:           aws.String(cluster),
+		ContainerInstance: aws.String(arn),
+	}, func(page *ecs.ListTasksOutput, lastPage bool) bool {
+		arns = append(arns, page.TaskArns...)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	// DescribeTasks accepts at most 100 tasks per call
+	for start := 0; start < len(arns); start += 100 {
+		end := start + 100
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		resp, err := svc.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   arns[start:end],
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		for _, task := range resp.Tasks {
+			if strings.HasPrefix(aws.StringValue(task.StartedBy), serviceTaskStartedByPrefix) {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// PollUntilDrained waits for a draining container instance to have no service tasks,
+// calling f whenever the number of tasks changes
+func PollUntilDrained(svc ecsInterface, cluster, arn string, timeout time.Duration, f func(count int)) error {
+	deadline := time.Now().Add(timeout)
+	lastCount := -1
+
+	for {
+		count, err := CountServiceTasks(svc, cluster, arn)
+		if err != nil {
+			return err
+		}
+
+		if count != lastCount {
+			f(count)
+			lastCount = count
+		}
+
+		if count == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Container instance %s still has %d service tasks after %s", arn, count, timeout)
+		}
+
+		time.Sleep(ECS_POLL_INTERVAL * 5)
+	}
+}
+
+// PollUntilDeregistered waits for a terminated container instance to become INACTIVE
+func PollUntilDeregistered(svc ecsInterface, cluster, arn string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		instance, err := FindContainerInstance(svc, cluster, arn)
+		if err != nil {
+			return err
+		}
+
+		if *instance.Status == ContainerInstanceStatusInactive {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Container instance %s is still %s after %s", arn, *instance.Status, timeout)
+		}
+
+		time.Sleep(ECS_POLL_INTERVAL * 5)
+	}
+}
+
+// PollUntilNoContainerInstances waits for all of a cluster's container instances to deregister,
+// which ECS does shortly after their EC2 instances terminate
+func PollUntilNoContainerInstances(svc ecsInterface, cluster string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		count := 0
+		err := svc.ListContainerInstancesPages(&ecs.ListContainerInstancesInput{
+			Cluster: aws.String(cluster),
+		}, func(page *ecs.ListContainerInstancesOutput, lastPage bool) bool {
+			count += len(page.ContainerInstanceArns)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d container instances are still registered with %s after %s", count, cluster, timeout)
+		}
+
+		time.Sleep(ECS_POLL_INTERVAL * 5)
+	}
+}
+
+// ContainerInstanceAttribute returns the value of an attribute of a container instance,
+// like ecs.ami-id or ecs.instance-type
+func ContainerInstanceAttribute(instance *ecs.ContainerInstance, name string) string {
+	for _, attr := range instance.Attributes {
+		if *attr.Name == name {
+			return aws.StringValue(attr.Value)
+		}
+	}
+	return ""
+}
+
+// PollUntilServicesStable waits for services to have a single deployment with all of their
+// tasks running, calling f with the services that aren't stable yet
+func PollUntilServicesStable(svc ecsInterface, cluster string, services []string, f func(pending []*ecs.Service)) error {
+	if len(services) == 0 {
+		return nil
+	}
+
+	lastPending := -1
+
+	for {
+		described, err := DescribeServices(svc, cluster, services)
+		if err != nil {
+			return err
+		}
+
+		pending := []*ecs.Service{}
+		for _, name := range services {
+			service, ok := described[name]
+			if !ok {
+				continue
+			}
+			if len(service.Deployments) != 1 || *service.RunningCount != *service.DesiredCount || *service.PendingCount > 0 {
+				pending = append(pending, service)
+			}
+		}
+
+		if len(pending) != lastPending {
+			f(pending)
+			lastPending = len(pending)
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		time.Sleep(ECS_POLL_INTERVAL * 5)
+	}
+}
+
+// TaskDefinitionRevision returns the revision from a task definition arn
+func TaskDefinitionRevision(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
 func PollUntilTaskDeployed(svc ecsInterface, cluster string, service string, task string, f func(e *ecs.ServiceEvent)) error {
 	lastSeen := time.Now().Add(-1 * time.Minute)
 
@@ -112,6 +533,81 @@ func PollUntilTaskDeployed(svc ecsInterface, cluster string, service string, tas
 	}
 }
 
+// PollUntilRunningCount waits for a service to have count running tasks and none pending,
+// calling f with the service's events
+func PollUntilRunningCount(svc ecsInterface, cluster string, service string, count int64, timeout time.Duration, f func(e *ecs.ServiceEvent)) error {
+	deadline := time.Now().Add(timeout)
+	lastSeen := time.Now().Add(-1 * time.Minute)
+
+	for {
+		service, err := getService(svc, cluster, service)
+		if err != nil {
+			return err
+		}
+
+		for i := len(service.Events) - 1; i >= 0; i-- {
+			event := service.Events[i]
+			if event.CreatedAt.After(lastSeen) {
+				f(event)
+				lastSeen = *event.CreatedAt
+			}
+		}
+
+		if *service.RunningCount == count && *service.PendingCount == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Service %s has %d running and %d pending tasks rather than %d after %s",
+				*service.ServiceName, *service.RunningCount, *service.PendingCount, count, timeout)
+		}
+
+		time.Sleep(ECS_POLL_INTERVAL)
+	}
+}
+
+func DeregisterTaskFamily(svc ecsInterface, family string, f func(arn string)) error {
+	arns := []*string{}
+
+	err := svc.ListTaskDefinitionsPages(&ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String(family),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+	}, func(page *ecs.ListTaskDefinitionsOutput, lastPage bool) bool {
+		for _, arn := range page.TaskDefinitionArns {
+			// family prefix matching also returns families like "app2"
+			if taskDefinitionFamily(*arn) == family {
+				arns = append(arns, arn)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, arn := range arns {
+		_, err := svc.DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{
+			TaskDefinition: arn,
+		})
+		if err != nil {
+			return err
+		}
+		f(*arn)
+	}
+
+	return nil
+}
+
+// taskDefinitionFamily extracts the family from an arn in the form
+// arn:aws:ecs:region:account:task-definition/family:revision
+func taskDefinitionFamily(arn string) string {
+	name := arn[strings.LastIndex(arn, "/")+1:]
+	if idx := strings.LastIndex(name, ":"); idx != -1 {
+		name = name[:idx]
+	}
+	return name
+}
+
 func ExposedPorts(taskDef *ecs.TaskDefinition) map[string][]*ecs.PortMapping {
 	mappings := map[string][]*ecs.PortMapping{}
 
@@ -129,3 +625,29 @@ func ExposedPorts(taskDef *ecs.TaskDefinition) map[string][]*ecs.PortMapping {
 
 	return mappings
 }
+
+type ExposedPort struct {
+	ContainerName string
+	Mapping       *ecs.PortMapping
+}
+
+// SortedExposedPorts returns the host mapped ports from ExposedPorts ordered by container
+// name, so the same task definition always produces the same order
+func SortedExposedPorts(taskDef *ecs.TaskDefinition) []ExposedPort {
+	mappings := ExposedPorts(taskDef)
+
+	names := []string{}
+	for name := range mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ports := []ExposedPort{}
+	for _, name := range names {
+		for _, mapping := range mappings[name] {
+			ports = append(ports, ExposedPort{ContainerName: name, Mapping: mapping})
+		}
+	}
+
+	return ports
+}
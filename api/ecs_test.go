@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+func TestSortedExposedPorts(t *testing.T) {
+	taskDef := &ecs.TaskDefinition{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name: aws.String("web"),
+				PortMappings: []*ecs.PortMapping{
+					{ContainerPort: aws.Int64(80), HostPort: aws.Int64(80)},
+					{ContainerPort: aws.Int64(9090), HostPort: aws.Int64(9090)},
+				},
+			},
+			{
+				Name: aws.String("worker"),
+				PortMappings: []*ecs.PortMapping{
+					{ContainerPort: aws.Int64(5672)},
+				},
+			},
+			{
+				Name: aws.String("admin"),
+				PortMappings: []*ecs.PortMapping{
+					{ContainerPort: aws.Int64(8080), HostPort: aws.Int64(8081)},
+				},
+			},
+		},
+	}
+
+	ports := SortedExposedPorts(taskDef)
+	if len(ports) != 3 {
+		t.Fatalf("Expected 3 exposed ports, got %d", len(ports))
+	}
+
+	for idx, expected := range []struct {
+		Container string
+		Port      int64
+	}{
+		{"admin", 8080},
+		{"web", 80},
+		{"web", 9090},
+	} {
+		if ports[idx].ContainerName != expected.Container || *ports[idx].Mapping.ContainerPort != expected.Port {
+			t.Errorf("Expected port %d to be %s:%d, got %s:%d", idx,
+				expected.Container, expected.Port, ports[idx].ContainerName, *ports[idx].Mapping.ContainerPort)
+		}
+	}
+}
+
+func TestTaskDefinitionFamily(t *testing.T) {
+	for arn, expected := range map[string]string{
+		"arn:aws:ecs:us-east-1:123456789012:task-definition/app:12":           "app",
+		"arn:aws:ecs:us-east-1:123456789012:task-definition/app_worker_run:3": "app_worker_run",
+	} {
+		if family := taskDefinitionFamily(arn); family != expected {
+			t.Errorf("Expected %q, got %q", expected, family)
+		}
+	}
+}
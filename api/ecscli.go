@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 )
@@ -9,10 +10,9 @@ import (
 type NetworkOutputs struct {
 	StackName      string
 	VpcId          string
-	Subnet0Public  string
-	Subnet1Public  string
-	Subnet2Private string
-	Subnet3Private string
+	VpcCidr        string
+	PublicSubnets  []string
+	PrivateSubnets []string
 }
 
 func FindClusterStack(svc cfnInterface, clusterName string) (*cloudformation.Stack, error) {
@@ -51,6 +51,52 @@ func FindServiceStack(svc cfnInterface, clusterName, taskFamily string) (*cloudf
 	return serviceStacks[0], nil
 }
 
+func FindScheduleStack(svc cfnInterface, clusterName, taskFamily string) (*cloudformation.Stack, error) {
+	scheduleStacks, err := FindStacksByOutputs(svc, map[string]string{
+		"StackType":  "ecs-former::ecs-schedule",
+		"ECSCluster": clusterName,
+		"TaskFamily": taskFamily,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(scheduleStacks) == 0 {
+		return nil, fmt.Errorf(
+			"Failed to find a cloudformation stack matching scheduled task %q, cluster %q",
+			taskFamily,
+			clusterName,
+		)
+	}
+	return scheduleStacks[0], nil
+}
+
+// FindClusterStacks returns the cluster stacks for all clusters
+func FindClusterStacks(svc cfnInterface) ([]*cloudformation.Stack, error) {
+	return FindStacksByOutputs(svc, map[string]string{
+		"StackType": "ecs-former::ecs-stack",
+	})
+}
+
+// FindServiceStacks returns the service stacks in a cluster, or in all clusters if
+// clusterName is empty
+func FindServiceStacks(svc cfnInterface, clusterName string) ([]*cloudformation.Stack, error) {
+	match := map[string]string{
+		"StackType": "ecs-former::ecs-service",
+	}
+	if clusterName != "" {
+		match["ECSCluster"] = clusterName
+	}
+	return FindStacksByOutputs(svc, match)
+}
+
+// FindScheduleStacks returns the schedule stacks in a cluster
+func FindScheduleStacks(svc cfnInterface, clusterName string) ([]*cloudformation.Stack, error) {
+	return FindStacksByOutputs(svc, map[string]string{
+		"StackType":  "ecs-former::ecs-schedule",
+		"ECSCluster": clusterName,
+	})
+}
+
 func FindNetworkStack(svc cfnInterface, clusterName string) (NetworkOutputs, error) {
 	stackName := clusterName + "-network"
 
@@ -59,25 +105,62 @@ func FindNetworkStack(svc cfnInterface, clusterName string) (NetworkOutputs, err
 		return NetworkOutputs{StackName: stackName}, err
 	}
 
+	// network stacks created before subnet lists had two fixed subnets of each kind
+	if _, ok := outputs["PublicSubnets"]; !ok {
+		return NetworkOutputs{
+			StackName:      stackName,
+			VpcId:          outputs["VpcId"],
+			VpcCidr:        "10.0.0.0/16",
+			PublicSubnets:  []string{outputs["Subnet0Public"], outputs["Subnet1Public"]},
+			PrivateSubnets: []string{outputs["Subnet2Private"], outputs["Subnet3Private"]},
+		}, nil
+	}
+
 	return NetworkOutputs{
 		StackName:      stackName,
 		VpcId:          outputs["VpcId"],
-		Subnet0Public:  outputs["Subnet0Public"],
-		Subnet1Public:  outputs["Subnet1Public"],
-		Subnet2Private: outputs["Subnet2Private"],
-		Subnet3Private: outputs["Subnet3Private"],
+		VpcCidr:        outputs["VpcCidr"],
+		PublicSubnets:  strings.Split(outputs["PublicSubnets"], ","),
+		PrivateSubnets: strings.Split(outputs["PrivateSubnets"], ","),
 	}, nil
 }
 
-func FindAllStacksForCluster(svc cfnInterface, clusterName string) ([]*cloudformation.Stack, error) {
+type ClusterStacks struct {
+	Services  []*cloudformation.Stack
+	Schedules []*cloudformation.Stack
+	Cluster   *cloudformation.Stack
+	Network   *cloudformation.Stack
+}
+
+func FindAllStacksForCluster(svc cfnInterface, clusterName string) (ClusterStacks, error) {
+	result := ClusterStacks{}
+
 	stacks, err := FindStacksByOutputs(svc, map[string]string{
 		"ECSCluster": clusterName,
 	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, stack := range stacks {
+		switch StackOutputMap(stack)["StackType"] {
+		case "ecs-former::ecs-stack":
+			result.Cluster = stack
+		case "ecs-former::ecs-schedule":
+			result.Schedules = append(result.Schedules, stack)
+		default:
+			result.Services = append(result.Services, stack)
+		}
+	}
 
-	networkStack, err := FindStacksByName(svc, clusterName+"-network")
-	if err == nil {
-		stacks = append(stacks, networkStack...)
+	// describing a stack by name fails if it doesn't exist
+	networkStacks, err := FindStacksByName(svc, clusterName+"-network")
+	if err != nil && !isStackNotExist(err) {
+		return result, err
+	}
+	if len(networkStacks) > 0 {
+		result.Network = networkStacks[0]
 	}
 
-	return stacks, nil
+	return result, nil
 }
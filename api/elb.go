@@ -0,0 +1,9 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/service/elb"
+)
+
+type elbInterface interface {
+	DescribeInstanceHealth(*elb.DescribeInstanceHealthInput) (*elb.DescribeInstanceHealthOutput, error)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+type elbv2Interface interface {
+	DescribeRules(*elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error)
+	DescribeTargetHealth(*elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error)
+	DescribeListeners(*elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error)
+}
+
+// ListenerRulePriorities returns the priorities of the rules on all of the given listeners,
+// leaving out the default rules
+func ListenerRulePriorities(svc elbv2Interface, listenerArns ...string) (map[int64]bool, error) {
+	used := map[int64]bool{}
+
+	for _, listenerArn := range listenerArns {
+		params := &elbv2.DescribeRulesInput{
+			ListenerArn: aws.String(listenerArn),
+		}
+
+		for {
+			resp, err := svc.DescribeRules(params)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, rule := range resp.Rules {
+				// the default rule has a priority of "default"
+				if priority, err := strconv.ParseInt(*rule.Priority, 10, 64); err == nil {
+					used[priority] = true
+				}
+			}
+
+			if resp.NextMarker == nil {
+				break
+			}
+			params.Marker = resp.NextMarker
+		}
+	}
+
+	return used, nil
+}
+
+// UnusedListenerRulePriority returns the lowest priority from min to max that isn't used
+func UnusedListenerRulePriority(used map[int64]bool, min, max int64) (int64, error) {
+	for priority := min; priority <= max; priority++ {
+		if !used[priority] {
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("All listener rule priorities from %d to %d are in use", min, max)
+}
+
+// ListenerPorts returns the ports a load balancer has listeners on
+func ListenerPorts(svc elbv2Interface, loadBalancerArn string) (map[int64]bool, error) {
+	ports := map[int64]bool{}
+	params := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	}
+
+	for {
+		resp, err := svc.DescribeListeners(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listener := range resp.Listeners {
+			ports[*listener.Port] = true
+		}
+
+		if resp.NextMarker == nil {
+			break
+		}
+		params.Marker = resp.NextMarker
+	}
+
+	return ports, nil
+}
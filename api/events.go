@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScheduleExpression converts a standard five field cron expression into an EventBridge
+// schedule expression. EventBridge expressions like rate(1 hour) are returned as is.
+func ScheduleExpression(cron string) (string, error) {
+	cron = strings.TrimSpace(cron)
+	if strings.HasPrefix(cron, "cron(") || strings.HasPrefix(cron, "rate(") {
+		return cron, nil
+	}
+
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("Expected 5 fields in cron expression %q", cron)
+	}
+
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	// EventBridge numbers days of the week from 1 (Sunday) rather than 0
+	dow, err := shiftDaysOfWeek(dow)
+	if err != nil {
+		return "", fmt.Errorf("Invalid day of week in cron expression %q: %v", cron, err)
+	}
+
+	// only one of day of month and day of week can be given, the other must be ?
+	switch {
+	case dow == "*":
+		dow = "?"
+	case dom == "*":
+		dom = "?"
+	default:
+		return "", fmt.Errorf("Cron expression %q can't restrict both the day of month and day of week", cron)
+	}
+
+	return fmt.Sprintf("cron(%s %s %s %s %s *)", minute, hour, dom, month, dow), nil
+}
+
+// shiftDaysOfWeek renumbers the days of a cron day of week field from 1 (Sunday). Ranges
+// ending with Sunday as 7 would wrap past Saturday, so they're written out as lists of days.
+func shiftDaysOfWeek(field string) (string, error) {
+	elems := strings.Split(field, ",")
+	for i, elem := range elems {
+		shifted, err := shiftDayOfWeekElement(elem)
+		if err != nil {
+			return "", err
+		}
+		elems[i] = shifted
+	}
+	return strings.Join(elems, ","), nil
+}
+
+func shiftDayOfWeekElement(elem string) (string, error) {
+	// a step like */2 is a count, not a day
+	base, step := elem, ""
+	if idx := strings.Index(elem, "/"); idx != -1 {
+		base, step = elem[:idx], elem[idx:]
+	}
+
+	bounds := strings.SplitN(base, "-", 2)
+	days := make([]int, len(bounds))
+	for i, bound := range bounds {
+		day, err := strconv.Atoi(bound)
+		if err != nil {
+			// * and names like MON-FRI mean the same to EventBridge
+			return elem, nil
+		} else if day < 0 || day > 7 {
+			return "", fmt.Errorf("day %d is out of range", day)
+		}
+		days[i] = day
+	}
+
+	if len(days) == 1 {
+		return strconv.Itoa(days[0]%7+1) + step, nil
+	}
+
+	first, last := days[0], days[1]
+	if first > last {
+		return "", fmt.Errorf("range %s ends before it starts", base)
+	} else if first == 0 && last == 7 {
+		last = 6
+	}
+
+	if last < 7 {
+		return fmt.Sprintf("%d-%d%s", first+1, last+1, step), nil
+	}
+
+	every := 1
+	if step != "" {
+		n, err := strconv.Atoi(step[1:])
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("step %s is invalid", step)
+		}
+		every = n
+	}
+
+	list := []string{}
+	for day := first; day <= last; day += every {
+		list = append(list, strconv.Itoa(day%7+1))
+	}
+	return strings.Join(list, ","), nil
+}
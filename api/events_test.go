@@ -0,0 +1,49 @@
+package api
+
+import "testing"
+
+func TestScheduleExpression(t *testing.T) {
+	for _, tc := range []struct {
+		cron, expected string
+	}{
+		{"0 3 * * *", "cron(0 3 * * ? *)"},
+		{"*/15 * * * *", "cron(*/15 * * * ? *)"},
+		{"30 2 1 * *", "cron(30 2 1 * ? *)"},
+		{"0 9 * * 1-5", "cron(0 9 ? * 2-6 *)"},
+		{"0 0 * * 0,6", "cron(0 0 ? * 1,7 *)"},
+		{"0 0 * * 7", "cron(0 0 ? * 1 *)"},
+		{"0 0 * * */2", "cron(0 0 ? * */2 *)"},
+		{"0 0 * * 5-7", "cron(0 0 ? * 6,7,1 *)"},
+		{"0 0 * * 6-7", "cron(0 0 ? * 7,1 *)"},
+		{"0 0 * * 1-7/2", "cron(0 0 ? * 2,4,6,1 *)"},
+		{"0 0 * * 0-7", "cron(0 0 ? * 1-7 *)"},
+		{"0 0 * * 1-3,5-7", "cron(0 0 ? * 2-4,6,7,1 *)"},
+		{"0 0 * * 1-5/2", "cron(0 0 ? * 2-6/2 *)"},
+		{"0 0 * * MON-FRI", "cron(0 0 ? * MON-FRI *)"},
+		{"rate(1 hour)", "rate(1 hour)"},
+		{"cron(0 3 * * ? *)", "cron(0 3 * * ? *)"},
+	} {
+		actual, err := ScheduleExpression(tc.cron)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.cron, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.cron, tc.expected, actual)
+		}
+	}
+}
+
+func TestScheduleExpressionErrors(t *testing.T) {
+	for _, cron := range []string{
+		"0 3 * *",
+		"0 3 1 * 1",
+		"0 3 * * 8",
+		"0 3 * * 5-1",
+		"0 3 * * 1-8",
+		"0 3 * * 5-7/0",
+	} {
+		if _, err := ScheduleExpression(cron); err == nil {
+			t.Errorf("%q: expected an error", cron)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+type route53Interface interface {
+	ListHostedZonesByName(*route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
+}
+
+// FindHostedZone finds the most specific hosted zone that can contain a dns name, preferring
+// a private zone for private names. The id is returned without the /hostedzone/ prefix.
+func FindHostedZone(svc route53Interface, dnsName string, private bool) (string, error) {
+	labels := strings.Split(strings.TrimSuffix(dnsName, "."), ".")
+
+	// walk up from the most specific domain, e.g app.example.com then example.com
+	for i := 0; i < len(labels)-1; i++ {
+		domain := strings.Join(labels[i:], ".") + "."
+
+		resp, err := svc.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+			DNSName: aws.String(domain),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		var match *route53.HostedZone
+		for _, zone := range resp.HostedZones {
+			if *zone.Name != domain {
+				continue
+			}
+			isPrivate := zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone)
+			if match == nil || isPrivate == private {
+				match = zone
+			}
+		}
+
+		if match != nil {
+			return strings.TrimPrefix(*match.Id, "/hostedzone/"), nil
+		}
+	}
+
+	return "", fmt.Errorf("No hosted zone found for %s", dnsName)
+}
@@ -4,17 +4,29 @@ import (
 	"log"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
 )
 
 var DefaultServices Services
 
 type Services struct {
+	ACM            acmInterface
+	AutoScaling    autoscalingInterface
 	Cloudformation cfnInterface
+	EC2            ec2Interface
 	ECS            ecsInterface
+	ELB            elbInterface
+	ELBV2          elbv2Interface
 	Logs           cloudwatchLogsInterface
+	Route53        route53Interface
 }
 
 func init() {
@@ -25,7 +37,13 @@ func init() {
 		log.Fatal(err)
 	}
 
+	DefaultServices.ACM = acm.New(sess)
+	DefaultServices.AutoScaling = autoscaling.New(sess)
 	DefaultServices.Cloudformation = cloudformation.New(sess)
+	DefaultServices.EC2 = ec2.New(sess)
 	DefaultServices.ECS = ecs.New(sess)
+	DefaultServices.ELB = elb.New(sess)
+	DefaultServices.ELBV2 = elbv2.New(sess)
 	DefaultServices.Logs = cloudwatchlogs.New(sess)
+	DefaultServices.Route53 = route53.New(sess)
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the public SSM parameters AWS keeps pointed at the latest ECS-optimized AMIs, by architecture
+var amiParameterAliases = map[string]map[string]string{
+	"x86_64": {
+		"al2":    "/aws/service/ecs/optimized-ami/amazon-linux-2/recommended/image_id",
+		"al2023": "/aws/service/ecs/optimized-ami/amazon-linux-2023/recommended/image_id",
+	},
+	"arm64": {
+		"al2":    "/aws/service/ecs/optimized-ami/amazon-linux-2/arm64/recommended/image_id",
+		"al2023": "/aws/service/ecs/optimized-ami/amazon-linux-2023/arm64/recommended/image_id",
+	},
+}
+
+// amiParams returns the cluster stack parameters for an --ami value, which is either
+// an alias, an SSM parameter path to resolve when the stack changes or an AMI ID to pin.
+// Aliases resolve to the AMI for the architecture of the instances.
+func amiParams(ami, arch string) (map[string]string, error) {
+	if ami == "" {
+		ami = "al2"
+	}
+
+	if parameter, ok := amiParameterAliases[arch][ami]; ok {
+		return map[string]string{"AmiParameter": parameter}, nil
+	} else if strings.HasPrefix(ami, "/") {
+		return map[string]string{"AmiParameter": ami}, nil
+	} else if strings.HasPrefix(ami, "ami-") {
+		return map[string]string{"AmiId": ami}, nil
+	}
+
+	return nil, fmt.Errorf("Invalid value %q for --ami, expected al2, al2023, an SSM parameter path or an AMI ID", ami)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAmiParams(t *testing.T) {
+	for _, tc := range []struct {
+		ami, arch string
+		expected  map[string]string
+	}{
+		{"", "x86_64", map[string]string{"AmiParameter": "/aws/service/ecs/optimized-ami/amazon-linux-2/recommended/image_id"}},
+		{"", "arm64", map[string]string{"AmiParameter": "/aws/service/ecs/optimized-ami/amazon-linux-2/arm64/recommended/image_id"}},
+		{"al2023", "x86_64", map[string]string{"AmiParameter": "/aws/service/ecs/optimized-ami/amazon-linux-2023/recommended/image_id"}},
+		{"al2023", "arm64", map[string]string{"AmiParameter": "/aws/service/ecs/optimized-ami/amazon-linux-2023/arm64/recommended/image_id"}},
+		{"/my/ami/parameter", "x86_64", map[string]string{"AmiParameter": "/my/ami/parameter"}},
+		{"ami-0123456789abcdef0", "arm64", map[string]string{"AmiId": "ami-0123456789abcdef0"}},
+	} {
+		actual, err := amiParams(tc.ami, tc.arch)
+		if err != nil {
+			t.Fatalf("%q %s: %v", tc.ami, tc.arch, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%q %s: expected %v, got %v", tc.ami, tc.arch, tc.expected, actual)
+		}
+	}
+
+	for _, ami := range []string{"ubuntu", "0123456789abcdef0"} {
+		if _, err := amiParams(ami, "x86_64"); err == nil {
+			t.Errorf("%q: expected an error", ami)
+		}
+	}
+}
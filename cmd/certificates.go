@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lox/ecsy/api"
+)
+
+// supports TLS 1.3 and 1.2 only, older clients will fail to negotiate
+const defaultSSLPolicy = "ELBSecurityPolicy-TLS13-1-2-2021-06"
+
+// resolveCertificate turns a domain name into the ARN of an issued ACM certificate,
+// ARNs and empty values are returned as is
+func resolveCertificate(svc api.Services, certificate string) (string, error) {
+	if certificate == "" || strings.HasPrefix(certificate, "arn:") {
+		return certificate, nil
+	}
+
+	log.Printf("Looking up an ACM certificate for %s", certificate)
+	arn, err := api.FindCertificate(svc.ACM, certificate)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("Using certificate %s", arn)
+	return arn, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -16,6 +17,9 @@ import (
 
 const (
 	stackDateFormat = "20060102-150405"
+
+	// the cluster template supports this many instance types in its auto scaling group
+	maxInstanceTypes = 4
 )
 
 func clusterStackName(cluster string) string {
@@ -23,10 +27,13 @@ func clusterStackName(cluster string) string {
 }
 
 func ConfigureCreateCluster(app *kingpin.Application, svc api.Services) {
-	var cluster, keyName, instanceType, dockerUsername, dockerPassword, dockerEmail, authorizedKeys string
-	var datadogKey, logspoutTarget string
-	var instanceCount int
+	var cluster, keyName, dockerUsername, dockerPassword, dockerEmail, authorizedKeys string
+	var datadogKey, logspoutTarget, certificate, sslPolicy, ami, spotStrategy string
+	var instanceTypes []string
+	var instanceCount, maxCount, targetCapacity, onDemandBase, onDemandPercentage int
 	var disableRollback bool
+	var vpc vpcFlags
+	var network networkFlags
 
 	cmd := app.Command("create-cluster", "Create an ECS cluster")
 	cmd.Flag("cluster", "The name of the ECS cluster to create").
@@ -37,14 +44,40 @@ func ConfigureCreateCluster(app *kingpin.Application, svc api.Services) {
 		Default("default").
 		StringVar(&keyName)
 
-	cmd.Flag("type", "The EC2 instance type to use").
+	cmd.Flag("type", "The EC2 instance type to use, e.g t3.medium or m7g.large. Can be repeated to launch whichever is available").
 		Default("t2.micro").
-		StringVar(&instanceType)
+		StringsVar(&instanceTypes)
+
+	cmd.Flag("on-demand-base", "The number of instances that are always on-demand").
+		Default("0").
+		IntVar(&onDemandBase)
+
+	cmd.Flag("on-demand-percentage", "The percentage of instances above the on-demand base that are on-demand, the rest are spot").
+		Default("100").
+		IntVar(&onDemandPercentage)
 
-	cmd.Flag("count", "The number of instances to use").
+	cmd.Flag("spot-allocation-strategy", "How spot instances are chosen from the instance types").
+		Default("price-capacity-optimized").
+		EnumVar(&spotStrategy, "price-capacity-optimized", "capacity-optimized", "capacity-optimized-prioritized", "lowest-price")
+
+	cmd.Flag("ami", "The AMI for instances, either al2, al2023, an SSM parameter path or an AMI ID to pin. Defaults to the latest ECS-optimized Amazon Linux 2 for the instance type").
+		StringVar(&ami)
+
+	cmd.Flag("count", "The number of instances to start with").
 		Default("3").
 		IntVar(&instanceCount)
 
+	cmd.Flag("max-count", "The most instances the capacity provider can scale out to").
+		Default("6").
+		IntVar(&maxCount)
+
+	cmd.Flag("target-capacity", "The percentage of the instances' capacity the capacity provider keeps in use").
+		Default("100").
+		IntVar(&targetCapacity)
+
+	vpc.register(cmd)
+	network.register(cmd)
+
 	cmd.Flag("docker-username", "The docker Username to use").
 		StringVar(&dockerUsername)
 
@@ -54,51 +87,153 @@ func ConfigureCreateCluster(app *kingpin.Application, svc api.Services) {
 	cmd.Flag("docker-email", "The docker Email to use").
 		StringVar(&dockerEmail)
 
-	cmd.Flag("datadog-key", "The datadog api key").
+	// per-host agents are better run as daemon services, these are kept for existing clusters
+	cmd.Flag("datadog-key", "The datadog api key, prefer running the agent as a daemon service").
 		StringVar(&datadogKey)
 
-	cmd.Flag("logspout-target", "The endpoint to push logspout output to").
+	cmd.Flag("logspout-target", "The endpoint to push logspout output to, prefer running logspout as a daemon service").
 		StringVar(&logspoutTarget)
 
 	cmd.Flag("authorized-keys", "A URL to fetch a SSH authorized_keys file from.").
 		StringVar(&authorizedKeys)
 
+	cmd.Flag("certificate", "The default certificate for the cluster's shared HTTPS listener, either an ACM certificate ARN or a domain to look one up for").
+		StringVar(&certificate)
+
+	// replaced by --certificate, kept for existing scripts
+	cmd.Flag("ssl-certificate-id", "The default SSL certificate for the cluster's shared HTTPS listener").
+		Hidden().
+		StringVar(&certificate)
+
+	cmd.Flag("ssl-policy", "The TLS security policy of the cluster's shared HTTPS listener").
+		Default(defaultSSLPolicy).
+		StringVar(&sslPolicy)
+
 	cmd.Flag("disable-rollback", "Don't rollback created infrastructure if a failure occurs").
 		BoolVar(&disableRollback)
 
 	cmd.Action(func(c *kingpin.ParseContext) error {
-		_, err := svc.ECS.CreateCluster(&ecs.CreateClusterInput{
-			ClusterName: aws.String(cluster),
-		})
+		if len(instanceTypes) > maxInstanceTypes {
+			return fmt.Errorf("Clusters support at most %d instance types", maxInstanceTypes)
+		}
+
+		if instanceCount > maxCount {
+			return fmt.Errorf("The instance count %d is greater than the maximum count %d", instanceCount, maxCount)
+		}
+
+		if targetCapacity < 1 || targetCapacity > 100 {
+			return fmt.Errorf("The target capacity must be a percentage between 1 and 100")
+		}
+
+		if onDemandBase < 0 || onDemandPercentage < 0 || onDemandPercentage > 100 {
+			return fmt.Errorf("The on-demand base must be positive and the on-demand percentage between 0 and 100")
+		}
+
+		instances, err := lookupInstanceTypes(instanceTypes)
+		if err != nil {
+			return err
+		}
+
+		amiParams, err := amiParams(ami, instances[0].Arch)
+		if err != nil {
+			return err
+		}
 
-		network, err := getOrCreateNetworkStack(cluster, disableRollback, svc)
+		certificateID, err := resolveCertificate(svc, certificate)
 		if err != nil {
 			return err
 		}
 
+		var vpcID, vpcCidr string
+		var privateSubnets, publicSubnets []string
+		var networkParams map[string]string
+
+		if vpc.isSet() {
+			if network.isSet() {
+				return fmt.Errorf("--vpc-cidr, --zones and --nat-gateways only apply to a new VPC, not a --vpc-id")
+			}
+			log.Printf("Using existing VPC %s", vpc.vpcID)
+			privateSubnets, publicSubnets, err = vpc.subnets(svc)
+			if err != nil {
+				return err
+			}
+			if vpcCidr, err = vpc.cidr(svc); err != nil {
+				return err
+			}
+			vpcID = vpc.vpcID
+		} else if networkParams, err = network.params(svc); err != nil {
+			return err
+		}
+
+		_, err = svc.ECS.CreateCluster(&ecs.CreateClusterInput{
+			ClusterName: aws.String(cluster),
+		})
+
+		if vpcID == "" {
+			outputs, err := getOrCreateNetworkStack(cluster, &network, networkParams, disableRollback, svc)
+			if err != nil {
+				return err
+			}
+			vpcID = outputs.VpcId
+			vpcCidr = outputs.VpcCidr
+			privateSubnets = outputs.PrivateSubnets
+			publicSubnets = outputs.PublicSubnets
+		}
+
+		if len(instances) == 1 {
+			log.Printf("Cluster will have %d vCPUs and %.1f GiB of memory with %d %s instances",
+				instances[0].VCPUs*int64(instanceCount), instances[0].MemoryGiB()*float64(instanceCount), instanceCount, instances[0].Name)
+		} else {
+			// the auto scaling group picks the types, so only the bounds are known
+			smallest, largest := instanceCapacityRange(instances)
+			log.Printf("Cluster will have %d-%d vCPUs and %.1f-%.1f GiB of memory with %d instances of %s",
+				smallest.VCPUs*int64(instanceCount), largest.VCPUs*int64(instanceCount),
+				smallest.MemoryGiB()*float64(instanceCount), largest.MemoryGiB()*float64(instanceCount),
+				instanceCount, strings.Join(instanceTypes, ", "))
+		}
+
+		if onDemandPercentage < 100 {
+			log.Printf("Instances above the first %d will be %d%% spot", onDemandBase, 100-onDemandPercentage)
+		}
+
 		timer := time.Now()
 		stackName := clusterStackName(cluster)
 		log.Printf("Creating cloudformation stack %s", stackName)
 
 		ctx := api.CreateStackContext{
 			Params: map[string]string{
-				"VpcId":               network.VpcId,
-				"VpcPrivateSubnet1Id": network.Subnet2Private,
-				"VpcPrivateSubnet2Id": network.Subnet3Private,
-				"KeyName":             keyName,
-				"ECSCluster":          cluster,
-				"InstanceType":        instanceType,
-				"DesiredCapacity":     strconv.Itoa(instanceCount),
-				"DockerHubUsername":   dockerUsername,
-				"DockerHubPassword":   dockerPassword,
-				"DockerHubEmail":      dockerEmail,
-				"LogspoutTarget":      logspoutTarget,
-				"DatadogApiKey":       datadogKey,
-				"AuthorizedUsersUrl":  authorizedKeys,
+				"VpcId":                  vpcID,
+				"VpcCidr":                vpcCidr,
+				"PrivateSubnets":         strings.Join(privateSubnets, ","),
+				"PublicSubnets":          strings.Join(publicSubnets, ","),
+				"KeyName":                keyName,
+				"ECSCluster":             cluster,
+				"DesiredCapacity":        strconv.Itoa(instanceCount),
+				"MaxSize":                strconv.Itoa(maxCount),
+				"CapacityTargetPercent":  strconv.Itoa(targetCapacity),
+				"DockerHubUsername":      dockerUsername,
+				"DockerHubPassword":      dockerPassword,
+				"DockerHubEmail":         dockerEmail,
+				"LogspoutTarget":         logspoutTarget,
+				"DatadogApiKey":          datadogKey,
+				"AuthorizedUsersUrl":     authorizedKeys,
+				"SSLCertificateId":       certificateID,
+				"SslPolicy":              sslPolicy,
+				"OnDemandBaseCapacity":   strconv.Itoa(onDemandBase),
+				"OnDemandPercentage":     strconv.Itoa(onDemandPercentage),
+				"SpotAllocationStrategy": spotStrategy,
 			},
 			DisableRollback: disableRollback,
 		}
 
+		for k, v := range amiParams {
+			ctx.Params[k] = v
+		}
+
+		for k, v := range instanceTypeParams(instanceTypes) {
+			ctx.Params[k] = v
+		}
+
 		err = api.CreateStack(svc.Cloudformation, stackName, templates.EcsStack(), ctx)
 		if err != nil {
 			return err
@@ -116,17 +251,24 @@ func ConfigureCreateCluster(app *kingpin.Application, svc api.Services) {
 	})
 }
 
-func getOrCreateNetworkStack(clusterName string, disableRollback bool, svc api.Services) (api.NetworkOutputs, error) {
+func getOrCreateNetworkStack(clusterName string, network *networkFlags, params map[string]string, disableRollback bool, svc api.Services) (api.NetworkOutputs, error) {
 	outputs, err := api.FindNetworkStack(svc.Cloudformation, clusterName)
 	if err == nil {
+		// the flags would be silently ignored, as an existing VPC's layout isn't changed
+		if network.isSet() {
+			return api.NetworkOutputs{}, fmt.Errorf(
+				"Network stack %s already exists, --vpc-cidr, --zones and --nat-gateways only apply to a new VPC", outputs.StackName)
+		}
+		log.Printf("Using existing network stack %s with %d availability zones", outputs.StackName, len(outputs.PrivateSubnets))
 		return outputs, nil
 	}
 
 	timer := time.Now()
-	log.Printf("Creating Network Stack for %s", clusterName)
+	log.Printf("Creating Network Stack for %s with %s across %s availability zones",
+		clusterName, params["VpcCidr"], params["AvailabilityZones"])
 
 	ctx := api.CreateStackContext{
-		Params:          map[string]string{},
+		Params:          params,
 		DisableRollback: disableRollback,
 	}
 
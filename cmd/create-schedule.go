@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/lox/ecsy/api"
+	"github.com/lox/ecsy/templates"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func scheduleTaskFamily(projectName, service string) string {
+	return fmt.Sprintf("%s_%s_schedule", projectName, service)
+}
+
+// stack names can't contain the underscores that compose service names often do
+func scheduleStackName(cluster, projectName, service string) string {
+	return strings.Replace(fmt.Sprintf("ecs-%s-%s-%s-schedule", cluster, projectName, service), "_", "-", -1)
+}
+
+func ConfigureCreateSchedule(app *kingpin.Application, svc api.Services) {
+	var cluster, projectName, service, cron string
+	var composeFiles []string
+	var count int
+	var disableRollback bool
+
+	cmd := app.Command("create-schedule", "Run a compose service as a task on a schedule")
+	cmd.Flag("cluster", "The name of the ECS cluster to use").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "The name of the Compose project").
+		Short('p').
+		Default(currentDirName()).
+		StringVar(&projectName)
+
+	cmd.Flag("file", "The paths to docker-compose files to convert to task definitions").
+		Short('f').
+		Default("docker-compose.yml").
+		ExistingFilesVar(&composeFiles)
+
+	cmd.Flag("service", "The name of compose service to run").
+		Short('s').
+		Required().
+		StringVar(&service)
+
+	cmd.Flag("cron", "When to run the task, either a cron expression like \"0 3 * * *\" (UTC) or an EventBridge expression like \"rate(1 hour)\"").
+		Required().
+		StringVar(&cron)
+
+	cmd.Flag("count", "The number of tasks to run each time").
+		Default("1").
+		IntVar(&count)
+
+	cmd.Flag("disable-rollback", "Don't rollback created infrastructure if a failure occurs").
+		BoolVar(&disableRollback)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		expression, err := api.ScheduleExpression(cron)
+		if err != nil {
+			return err
+		}
+
+		family := scheduleTaskFamily(projectName, service)
+		log.Printf("Creating schedule for %s on %s", family, cluster)
+
+		if stack, _ := api.FindScheduleStack(svc.Cloudformation, cluster, family); stack != nil {
+			return fmt.Errorf("A schedule already exists for %q in cluster %q. Use `delete-schedule` first",
+				family, cluster)
+		}
+
+		taskDef, _, err := registerComposeServiceTask(svc, cluster, projectName, service, family, composeFiles)
+		if err != nil {
+			return err
+		}
+		log.Printf("Registered task definition %s:%d", *taskDef.Family, *taskDef.Revision)
+
+		timer := time.Now()
+		stackName := scheduleStackName(cluster, projectName, service)
+		log.Printf("Creating schedule cloudformation stack %s to run %s", stackName, expression)
+
+		err = api.CreateStack(svc.Cloudformation, stackName, templates.EcsSchedule(), api.CreateStackContext{
+			Params: map[string]string{
+				"ECSCluster":         cluster,
+				"TaskFamily":         family,
+				"TaskDefinition":     *taskDef.TaskDefinitionArn,
+				"ScheduleExpression": expression,
+				"TaskCount":          strconv.Itoa(count),
+			},
+			DisableRollback: disableRollback,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = api.PollUntilCreated(svc.Cloudformation, stackName, func(event *cloudformation.StackEvent) {
+			log.Printf("%s\n", api.FormatStackEvent(event))
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Schedule created in %s", time.Now().Sub(timer).String())
+		return nil
+	})
+}
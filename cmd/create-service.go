@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,14 +19,24 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
+// the service template supports the first port plus two extra listeners
+const maxServicePorts = 3
+
+// services with this scheduling strategy run a task on every instance in the cluster
+const daemonSchedulingStrategy = "DAEMON"
+
 func serviceStackName(cluster, taskFamily string) string {
 	return fmt.Sprintf("ecs-%s-%s-service", cluster, taskFamily)
 }
 
 func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
-	var cluster, projectName, healthCheck, certificateID string
-	var composeFiles []string
-	var disableRollback bool
+	var cluster, projectName, certificate, taskRole, taskPolicy, host, path, dnsName string
+	var composeFiles, allowedCidrs []string
+	var disableRollback, serviceDiscovery, internal, redirectHTTPS bool
+	var priority int64
+	var scaling scalingFlags
+	var healthChecks healthCheckFlags
+	var placement placementFlags
 
 	cmd := app.Command("create-service", "Create an ECS service for your app")
 	cmd.Flag("cluster", "The name of the ECS cluster to use").
@@ -36,13 +48,44 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 		Default(currentDirName()).
 		StringVar(&projectName)
 
-	cmd.Flag("healthcheck", "Path to check for HTTP health check").
-		Default("/").
-		StringVar(&healthCheck)
+	cmd.Flag("certificate", "Serve the service over HTTPS with either an ACM certificate ARN or a domain to look one up for").
+		StringVar(&certificate)
 
+	// replaced by --certificate, kept for existing scripts
 	cmd.Flag("ssl-certificate-id", "The identifier of the SSL certificate to associate with the service").
-		Default("").
-		StringVar(&certificateID)
+		Hidden().
+		StringVar(&certificate)
+
+	cmd.Flag("redirect-https", "Redirect HTTP requests for the service to HTTPS").
+		BoolVar(&redirectHTTPS)
+
+	cmd.Flag("host", "Only route requests with this host header to the service").
+		StringVar(&host)
+
+	cmd.Flag("path", "Only route requests matching this path pattern to the service").
+		Default("/*").
+		StringVar(&path)
+
+	cmd.Flag("priority", "The priority of the service's rules on the shared listeners, lower priorities are matched first. Defaults to after the rules of more specific hosts and paths").
+		Int64Var(&priority)
+
+	cmd.Flag("dns-name", "A DNS name to point at the service, the hosted zone is looked up in Route53").
+		StringVar(&dnsName)
+
+	cmd.Flag("internal", "Use a dedicated load balancer in the private subnets instead of the cluster's shared one").
+		BoolVar(&internal)
+
+	cmd.Flag("allow-cidr", "Only route requests from this CIDR block to the service. Can be repeated").
+		StringsVar(&allowedCidrs)
+
+	cmd.Flag("service-discovery", "Register the service in the cluster's private DNS namespace as <project>.<cluster>.local").
+		BoolVar(&serviceDiscovery)
+
+	cmd.Flag("task-role", "The ARN of an existing IAM role for the service's tasks, otherwise a role is created").
+		StringVar(&taskRole)
+
+	cmd.Flag("task-policy", "A file with an IAM policy for the created task role, defaults to task-policy.json next to the compose file").
+		ExistingFileVar(&taskPolicy)
 
 	cmd.Flag("file", "The paths to docker-compose files to convert to service definitions").
 		Short('f').
@@ -52,9 +95,36 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 	cmd.Flag("disable-rollback", "Don't rollback created infrastructure if a failure occurs").
 		BoolVar(&disableRollback)
 
+	scaling.register(cmd)
+	healthChecks.register(cmd)
+	placement.register(cmd)
+
 	cmd.Action(func(c *kingpin.ParseContext) error {
 		log.Printf("Creating service %s on %s", projectName, cluster)
 
+		// requests for the friendly name are the ones that should reach the service
+		if dnsName != "" && host == "" {
+			host = dnsName
+		}
+
+		if err := validateAllowedCidrs(allowedCidrs, host != ""); err != nil {
+			return err
+		}
+
+		healthCheckParams, err := healthChecks.params(nil)
+		if err != nil {
+			return err
+		}
+
+		if redirectHTTPS && certificate == "" {
+			return fmt.Errorf("Redirecting to HTTPS needs a --certificate")
+		}
+
+		certificateID, err := resolveCertificate(svc, certificate)
+		if err != nil {
+			return err
+		}
+
 		clusterStack, err := api.FindClusterStack(svc.Cloudformation, cluster)
 		if err != nil {
 			return err
@@ -81,6 +151,19 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 			return err
 		}
 
+		daemon, err := compose.IsDaemon(taskDefinitionInput)
+		if err != nil {
+			return err
+		}
+		if daemon && scaling.isSet() {
+			return fmt.Errorf("Daemon services run one task on every instance and can't be scaled")
+		}
+
+		placementParams, err := placement.params(daemon)
+		if err != nil {
+			return err
+		}
+
 		clusterOutput, err := api.StackOutputs(svc.Cloudformation, *clusterStack.StackName)
 		if err != nil {
 			return err
@@ -103,6 +186,31 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 			}
 		}
 
+		taskPolicy = findTaskPolicyFile(taskPolicy, composeFiles)
+		roleArn, roleName, policyDocument := taskRole, "", ""
+
+		// the role is created by the service stack, so it's removed along with the service
+		if taskRole == "" {
+			roleName = taskRoleName(cluster, projectName)
+			log.Printf("Creating task role %s", roleName)
+
+			if roleArn, err = taskRoleArn(clusterStack, roleName); err != nil {
+				return err
+			}
+
+			if taskPolicy != "" {
+				log.Printf("Applying policy %s to task role %s", taskPolicy, roleName)
+				if policyDocument, err = readTaskPolicy(taskPolicy); err != nil {
+					return err
+				}
+			}
+		} else if taskPolicy != "" {
+			log.Printf("Ignoring %s, policies are only applied to task roles created by ecsy", taskPolicy)
+		}
+
+		log.Printf("Setting tasks to use role %s", roleArn)
+		taskDefinitionInput.TaskRoleArn = aws.String(roleArn)
+
 		log.Printf("Registering a task for %s", projectName)
 		resp, err := svc.ECS.RegisterTaskDefinition(taskDefinitionInput)
 		if err != nil {
@@ -110,39 +218,158 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 		}
 		log.Printf("Registered task definition %s:%d", *resp.TaskDefinition.Family, *resp.TaskDefinition.Revision)
 
-		network, err := api.FindNetworkStack(svc.Cloudformation, cluster)
-		if err != nil {
-			return err
+		ports := []api.ExposedPort{}
+		for _, port := range api.SortedExposedPorts(resp.TaskDefinition) {
+			if port.Mapping.Protocol != nil && *port.Mapping.Protocol == "udp" {
+				log.Printf("Skipping udp port %d on %s, ALBs only support tcp", *port.Mapping.HostPort, port.ContainerName)
+				continue
+			}
+			ports = append(ports, port)
+		}
+
+		if len(ports) > maxServicePorts {
+			return fmt.Errorf("Task definitions with more than %d host mapped ports are not supported", maxServicePorts)
 		}
-		log.Printf("Found network stack %s", network.StackName)
 
 		ctx := api.CreateStackContext{
 			Params: map[string]string{
-				"VpcId":              network.VpcId,
-				"VpcPublicSubnet1Id": network.Subnet0Public,
-				"VpcPublicSubnet2Id": network.Subnet1Public,
-				"ECSCluster":         cluster,
-				"ECSSecurityGroup":   clusterOutput["SecurityGroup"],
-				"TaskFamily":         *resp.TaskDefinition.Family,
-				"TaskDefinition":     *resp.TaskDefinition.TaskDefinitionArn,
-				"SSLCertificateId":   certificateID,
+				"VpcId":          clusterOutput["VpcId"],
+				"ECSCluster":     cluster,
+				"TaskFamily":     *resp.TaskDefinition.Family,
+				"TaskDefinition": *resp.TaskDefinition.TaskDefinitionArn,
+				"TaskRoleArn":    taskRole,
+				"TaskRoleName":   roleName,
+				"TaskPolicy":     policyDocument,
 			},
 			DisableRollback: disableRollback,
 		}
 
-		exposedPorts := api.ExposedPorts(resp.TaskDefinition)
+		for k, v := range placementParams {
+			ctx.Params[k] = v
+		}
+
+		if daemon {
+			log.Printf("Creating a daemon service that runs on every instance")
+			ctx.Params["SchedulingStrategy"] = daemonSchedulingStrategy
+		} else if provider, ok := clusterOutput["CapacityProvider"]; ok {
+			log.Printf("Tasks will be placed with capacity provider %s", provider)
+		}
+
+		if len(ports) == 0 {
+			if dnsName != "" {
+				return fmt.Errorf("A DNS name needs a service with a host mapped port to route to")
+			}
+			log.Printf("No host mapped ports found, creating service without a load balancer")
+		} else {
+			if err = clusterOutput.RequireKeys("LoadBalancer", "LoadBalancerDNSName", "HTTPListener"); err != nil {
+				return fmt.Errorf("Cluster %q has no shared load balancer: %v", cluster, err)
+			}
+
+			if internal {
+				// the dedicated internal listeners have no other rules to avoid
+				priority = 1
+
+				if err = clusterOutput.RequireKeys("PrivateSubnets", "SecurityGroup"); err != nil {
+					return fmt.Errorf("Cluster %q doesn't support internal load balancers: %v", cluster, err)
+				}
+				log.Printf("Creating an internal load balancer in the private subnets")
+
+				ctx.Params["Internal"] = "true"
+				ctx.Params["PrivateSubnets"] = clusterOutput["PrivateSubnets"]
+				ctx.Params["InstanceSecurityGroup"] = clusterOutput["SecurityGroup"]
+			} else {
+				listeners := []string{clusterOutput["HTTPListener"]}
+				if certificateID != "" {
+					if _, exists := clusterOutput["HTTPSListener"]; !exists {
+						return fmt.Errorf("Cluster %q has no HTTPS listener. Use `create-cluster --ssl-certificate-id`", cluster)
+					}
+					listeners = append(listeners, clusterOutput["HTTPSListener"])
+				}
+
+				priority, err = listenerRulePriority(svc, listeners, host, path, allowedCidrs, priority)
+				if err != nil {
+					return err
+				}
+				log.Printf("Routing requests to the service with listener rule priority %d", priority)
+			}
+
+			ctx.Params["LoadBalancer"] = clusterOutput["LoadBalancer"]
+			ctx.Params["LoadBalancerDNSName"] = clusterOutput["LoadBalancerDNSName"]
+			ctx.Params["LoadBalancerFullName"] = clusterOutput["LoadBalancerFullName"]
+			ctx.Params["LoadBalancerSecurityGroup"] = clusterOutput["LoadBalancerSecurityGroup"]
+			ctx.Params["HTTPListener"] = clusterOutput["HTTPListener"]
+			ctx.Params["HTTPSListener"] = clusterOutput["HTTPSListener"]
+			ctx.Params["ListenerRulePriority"] = strconv.FormatInt(priority, 10)
+			ctx.Params["AllowedCidrs"] = strings.Join(allowedCidrs, ",")
+			for idx, cidr := range allowedCidrs {
+				ctx.Params["AllowedCidr"+strconv.Itoa(idx+1)] = cidr
+			}
+			ctx.Params["HostHeader"] = host
+
+			if dnsName != "" {
+				zoneID, err := api.FindHostedZone(svc.Route53, dnsName, internal)
+				if err != nil {
+					return err
+				}
+				log.Printf("Creating a DNS record for %s in hosted zone %s", dnsName, zoneID)
+
+				ctx.Params["DNSName"] = dnsName
+				ctx.Params["HostedZoneId"] = zoneID
+				ctx.Params["LoadBalancerHostedZoneId"] = clusterOutput["LoadBalancerHostedZoneId"]
+			}
+			ctx.Params["PathPattern"] = path
+			ctx.Params["SSLCertificateId"] = certificateID
+			ctx.Params["RedirectToHttps"] = strconv.FormatBool(redirectHTTPS)
+			for k, v := range healthCheckParams {
+				ctx.Params[k] = v
+			}
+
+			// the first port is routed to via the shared listeners, the rest get their own
+			ctx.Params["ContainerName"] = ports[0].ContainerName
+			ctx.Params["ContainerPort"] = strconv.FormatInt(*ports[0].Mapping.ContainerPort, 10)
+
+			if err = checkExtraListenerPorts(svc, ports[1:], clusterOutput["LoadBalancer"], internal); err != nil {
+				return err
+			}
+
+			for idx, port := range ports[1:] {
+				suffix := strconv.Itoa(idx + 1)
+				ctx.Params["ExtraContainerName"+suffix] = port.ContainerName
+				ctx.Params["ExtraContainerPort"+suffix] = strconv.FormatInt(*port.Mapping.ContainerPort, 10)
+				ctx.Params["ExtraListenerPort"+suffix] = strconv.FormatInt(*port.Mapping.HostPort, 10)
+				if !internal {
+					log.Printf("Port %d on %s will be available at http://%s:%d",
+						*port.Mapping.ContainerPort, port.ContainerName, clusterOutput["LoadBalancerDNSName"], *port.Mapping.HostPort)
+				}
+			}
+		}
+
+		if serviceDiscovery {
+			if err = clusterOutput.RequireKeys("ServiceDiscoveryNamespace", "ServiceDiscoveryNamespaceName"); err != nil {
+				return fmt.Errorf("Cluster %q has no service discovery namespace: %v", cluster, err)
+			}
 
-		if len(exposedPorts) != 1 {
-			return fmt.Errorf("Task definition without exactly 1 host mapped port are not yet supported")
+			exposed := api.SortedExposedPorts(resp.TaskDefinition)
+			if len(exposed) == 0 {
+				return fmt.Errorf("Service discovery needs a container with a mapped port to publish")
+			}
+
+			ctx.Params["ServiceDiscoveryNamespace"] = clusterOutput["ServiceDiscoveryNamespace"]
+			ctx.Params["ServiceDiscoveryName"] = projectName
+			ctx.Params["DiscoveryContainerName"] = exposed[0].ContainerName
+			ctx.Params["DiscoveryContainerPort"] = strconv.FormatInt(*exposed[0].Mapping.ContainerPort, 10)
+
+			log.Printf("Port %d on %s will be discoverable at %s.%s",
+				*exposed[0].Mapping.ContainerPort, exposed[0].ContainerName, projectName, clusterOutput["ServiceDiscoveryNamespaceName"])
 		}
 
-		// for now this is a single value
-		for container, mappings := range exposedPorts {
-			for _, mapping := range mappings {
-				ctx.Params["ContainerName"] = container
-				ctx.Params["ContainerPort"] = strconv.FormatInt(*mapping.ContainerPort, 10)
-				ctx.Params["HealthCheckUrl"] = healthCheck
-				ctx.Params["ELBPort"] = strconv.FormatInt(*mapping.HostPort, 10)
+		if !daemon {
+			scalingParams, err := scaling.params(nil, len(ports) > 0)
+			if err != nil {
+				return err
+			}
+			for k, v := range scalingParams {
+				ctx.Params[k] = v
 			}
 		}
 
@@ -184,7 +411,9 @@ func ConfigureCreateService(app *kingpin.Application, svc api.Services) {
 		// }
 
 		log.Printf("Service created in %s", time.Now().Sub(timer).String())
-		log.Printf("Service available at %s", stackOutputs["ECSLoadBalancer"])
+		if url, exists := stackOutputs["ECSLoadBalancer"]; exists {
+			log.Printf("Service available at %s", url)
+		}
 		return nil
 	})
 }
@@ -196,3 +425,96 @@ func currentDirName() string {
 	}
 	return filepath.Base(cwd)
 }
+
+// listener rules can have at most five condition values, the path pattern and host header use two
+func validateAllowedCidrs(cidrs []string, hasHost bool) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("Invalid CIDR block %q for --allow-cidr", cidr)
+		}
+	}
+
+	max := 4
+	if hasHost {
+		max = 3
+	}
+	if len(cidrs) > max {
+		return fmt.Errorf("At most %d CIDR blocks can be allowed for a service", max)
+	}
+
+	return nil
+}
+
+// checkExtraListenerPorts makes sure the listeners for a service's extra ports can be created,
+// they need a fixed host port that isn't already a listener on the shared load balancer
+func checkExtraListenerPorts(svc api.Services, ports []api.ExposedPort, loadBalancer string, internal bool) error {
+	// a dedicated internal load balancer only has the service's HTTP and HTTPS listeners
+	used := map[int64]bool{80: true, 443: true}
+
+	if !internal && len(ports) > 0 {
+		var err error
+		if used, err = api.ListenerPorts(svc.ELBV2, loadBalancer); err != nil {
+			return err
+		}
+	}
+
+	for _, port := range ports {
+		hostPort := aws.Int64Value(port.Mapping.HostPort)
+		if hostPort == 0 {
+			return fmt.Errorf("Port %d on %s has a dynamic host port, extra ports need a fixed host port to listen on",
+				*port.Mapping.ContainerPort, port.ContainerName)
+		} else if used[hostPort] {
+			return fmt.Errorf("Port %d on %s can't be exposed, the load balancer already has a listener on port %d",
+				*port.Mapping.ContainerPort, port.ContainerName, hostPort)
+		}
+		used[hostPort] = true
+	}
+
+	return nil
+}
+
+// listener rules are matched in priority order, so each kind of rule gets a band of priorities
+// that puts rules for a host and path before broader ones
+const listenerRuleBandSize = 10000
+
+func isCatchAllPath(path string) bool {
+	return path == "" || path == "*" || path == "/*"
+}
+
+// listenerRulePriority returns the priority for a service's rules on the shared listeners,
+// either the requested one or the first unused one in the band for how specific the rules are
+func listenerRulePriority(svc api.Services, listeners []string, host, path string, allowedCidrs []string, requested int64) (int64, error) {
+	used, err := api.ListenerRulePriorities(svc.ELBV2, listeners...)
+	if err != nil {
+		return 0, err
+	}
+
+	if requested != 0 {
+		if requested < 1 || requested > 5*listenerRuleBandSize-1 {
+			return 0, fmt.Errorf("The priority must be between 1 and %d", 5*listenerRuleBandSize-1)
+		} else if used[requested] {
+			return 0, fmt.Errorf("Listener rule priority %d is already in use", requested)
+		}
+		return requested, nil
+	}
+
+	var band int64
+	switch {
+	case host != "" && !isCatchAllPath(path):
+		band = 0
+	case host != "":
+		band = 1
+	case !isCatchAllPath(path):
+		band = 2
+	case len(allowedCidrs) > 0:
+		band = 3
+	default:
+		// a rule that matches every request only makes sense on a listener without other services
+		if len(used) > 0 {
+			return 0, fmt.Errorf("A service without a --host or --path would receive every request on the shared listener, which already has %d rules. Use --host or --path", len(used))
+		}
+		band = 4
+	}
+
+	return api.UnusedListenerRulePriority(used, band*listenerRuleBandSize+1, (band+1)*listenerRuleBandSize-1)
+}
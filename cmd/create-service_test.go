@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/lox/ecsy/api"
+)
+
+// fakeELBV2 returns rules with the given priorities for each listener
+type fakeELBV2 struct {
+	priorities map[string][]string
+}
+
+func (f fakeELBV2) DescribeRules(input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error) {
+	output := &elbv2.DescribeRulesOutput{
+		Rules: []*elbv2.Rule{{Priority: aws.String("default")}},
+	}
+	for _, priority := range f.priorities[*input.ListenerArn] {
+		output.Rules = append(output.Rules, &elbv2.Rule{Priority: aws.String(priority)})
+	}
+	return output, nil
+}
+
+func (f fakeELBV2) DescribeTargetHealth(*elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	return &elbv2.DescribeTargetHealthOutput{}, nil
+}
+
+func (f fakeELBV2) DescribeListeners(*elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error) {
+	return &elbv2.DescribeListenersOutput{}, nil
+}
+
+func TestListenerRulePriority(t *testing.T) {
+	svc := api.Services{
+		ELBV2: fakeELBV2{priorities: map[string][]string{
+			"http":  {"1", "10001"},
+			"https": {"2", "20001"},
+		}},
+	}
+
+	for _, tc := range []struct {
+		host, path string
+		cidrs      []string
+		requested  int64
+		expected   int64
+	}{
+		{"app.example.org", "/api/*", nil, 0, 3},
+		{"app.example.org", "", nil, 0, 10002},
+		{"app.example.org", "/*", nil, 0, 10002},
+		{"", "/api/*", nil, 0, 20002},
+		{"", "", []string{"10.0.0.0/8"}, 0, 30001},
+		{"", "/api/*", nil, 5, 5},
+	} {
+		actual, err := listenerRulePriority(svc, []string{"http", "https"}, tc.host, tc.path, tc.cidrs, tc.requested)
+		if err != nil {
+			t.Fatalf("%q %q: %v", tc.host, tc.path, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("%q %q: expected priority %d, got %d", tc.host, tc.path, tc.expected, actual)
+		}
+	}
+
+	// a catch-all rule is fine on listeners without other services
+	empty := api.Services{ELBV2: fakeELBV2{}}
+	if priority, err := listenerRulePriority(empty, []string{"http"}, "", "", nil, 0); err != nil || priority != 40001 {
+		t.Errorf("Expected a catch-all rule to get priority 40001, got %d (%v)", priority, err)
+	}
+}
+
+func TestListenerRulePriorityErrors(t *testing.T) {
+	svc := api.Services{
+		ELBV2: fakeELBV2{priorities: map[string][]string{
+			"http": {"1"},
+		}},
+	}
+
+	for _, tc := range []struct {
+		host, path string
+		requested  int64
+	}{
+		{"", "", 0},
+		{"", "/*", 0},
+		{"app.example.org", "", 1},
+		{"app.example.org", "", 50000},
+		{"app.example.org", "", -1},
+	} {
+		if _, err := listenerRulePriority(svc, []string{"http"}, tc.host, tc.path, nil, tc.requested); err == nil {
+			t.Errorf("%q %q %d: expected an error", tc.host, tc.path, tc.requested)
+		}
+	}
+}
+
+func TestValidateAllowedCidrs(t *testing.T) {
+	for _, tc := range []struct {
+		cidrs   []string
+		hasHost bool
+		valid   bool
+	}{
+		{nil, false, true},
+		{[]string{"10.0.0.0/8"}, true, true},
+		{[]string{"10.0.0.0/8", "192.168.0.0/16", "172.16.0.0/12", "203.0.113.7/32"}, false, true},
+		{[]string{"10.0.0.0/8", "192.168.0.0/16", "172.16.0.0/12", "203.0.113.7/32"}, true, false},
+		{[]string{"10.0.0.0/8", "192.168.0.0/16", "172.16.0.0/12", "203.0.113.7/32", "198.51.100.0/24"}, false, false},
+		{[]string{"10.0.0.0"}, false, false},
+		{[]string{"10.0.0.0/33"}, false, false},
+	} {
+		err := validateAllowedCidrs(tc.cidrs, tc.hasHost)
+		if tc.valid && err != nil {
+			t.Errorf("%v (host %v): %v", tc.cidrs, tc.hasHost, err)
+		} else if !tc.valid && err == nil {
+			t.Errorf("%v (host %v): expected an error", tc.cidrs, tc.hasHost)
+		}
+	}
+}
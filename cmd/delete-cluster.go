@@ -1,45 +1,174 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/lox/ecsy/api"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
+// how long terminated instances have to deregister from the cluster
+const instanceDeregisterTimeout = 10 * time.Minute
+
 func ConfigureDeleteCluster(app *kingpin.Application, svc api.Services) {
 	var cluster string
+	var force bool
 
 	cmd := app.Command("delete-cluster", "Deletes a cluster and all running services on it")
 	cmd.Flag("cluster", "The name of the ECS cluster to delete").
 		Required().
 		StringVar(&cluster)
 
-	cmd.Action(func(c *kingpin.ParseContext) error {
-		fmt.Printf("Deleting cluster %s", cluster)
-		timer := time.Now()
+	cmd.Flag("force", "Don't ask for confirmation before deleting").
+		BoolVar(&force)
 
+	cmd.Action(func(c *kingpin.ParseContext) error {
 		stacks, err := api.FindAllStacksForCluster(svc.Cloudformation, cluster)
 		if err != nil {
 			return err
 		}
 
-		for _, stack := range stacks {
-			err := api.DeleteStack(svc.Cloudformation, *stack.StackName)
-			if err != nil {
+		clusterExists, err := api.ClusterExists(svc.ECS, cluster)
+		if err != nil {
+			return err
+		}
+
+		if len(stacks.Services) == 0 && len(stacks.Schedules) == 0 && stacks.Cluster == nil && stacks.Network == nil && !clusterExists {
+			fmt.Printf("Nothing to delete for cluster %s\n", cluster)
+			return nil
+		}
+
+		fmt.Printf("The following will be deleted for cluster %s:\n", cluster)
+		for _, stack := range stacks.Services {
+			fmt.Printf("  Service stack %s\n", *stack.StackName)
+		}
+		for _, stack := range stacks.Schedules {
+			fmt.Printf("  Schedule stack %s\n", *stack.StackName)
+		}
+		if stacks.Cluster != nil {
+			fmt.Printf("  Cluster stack %s\n", *stacks.Cluster.StackName)
+		}
+		if clusterExists {
+			fmt.Printf("  ECS cluster %s\n", cluster)
+		}
+		if stacks.Network != nil {
+			fmt.Printf("  Network stack %s\n", *stacks.Network.StackName)
+		}
+
+		if !force && !confirm("Are you sure you want to continue?") {
+			return fmt.Errorf("Aborted deleting cluster %s", cluster)
+		}
+
+		fmt.Printf("Deleting cluster %s\n", cluster)
+		timer := time.Now()
+
+		if err = deleteStacksInParallel(svc, append(stacks.Services, stacks.Schedules...)); err != nil {
+			return err
+		}
+
+		if stacks.Cluster != nil {
+			// instances the capacity provider protected would keep the auto scaling group from being deleted
+			if group, ok := api.GetStackOutputByKey(stacks.Cluster, "AutoScalingGroup"); ok {
+				fmt.Printf("Removing scale in protection from instances in %s\n", group)
+				if err = api.UnprotectInstances(svc.AutoScaling, group); err != nil {
+					return err
+				}
+			}
+
+			if err = deleteStack(svc, stacks.Cluster); err != nil {
 				return err
 			}
+		}
 
-			err = api.PollUntilDeleted(svc.Cloudformation, *stack.StackName, func(event *cloudformation.StackEvent) {
-				fmt.Printf("%s\n", api.FormatStackEvent(event))
+		if clusterExists {
+			// a cluster can't be deleted while the terminated instances are still registered with it
+			fmt.Printf("Waiting for container instances to deregister from %s\n", cluster)
+			if err = api.PollUntilNoContainerInstances(svc.ECS, cluster, instanceDeregisterTimeout); err != nil {
+				return err
+			}
+
+			fmt.Printf("Deleting ECS cluster %s\n", cluster)
+			_, err = svc.ECS.DeleteCluster(&ecs.DeleteClusterInput{
+				Cluster: aws.String(cluster),
 			})
+			if err != nil {
+				return err
+			}
+		}
 
-			fmt.Printf("Deleted stack %s\n", *stack.StackName)
+		if stacks.Network != nil {
+			if err = deleteStack(svc, stacks.Network); err != nil {
+				return err
+			}
 		}
 
 		fmt.Printf("Cluster %s deleted in %s\n\n", cluster, time.Now().Sub(timer).String())
 		return nil
 	})
 }
+
+func deleteStack(svc api.Services, stack *cloudformation.Stack) error {
+	fmt.Printf("Deleting stack %s\n", *stack.StackName)
+
+	err := api.DeleteStackAndWait(svc.Cloudformation, stack, func(event *cloudformation.StackEvent) {
+		fmt.Printf("%s: %s\n", *stack.StackName, api.FormatStackEvent(event))
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to delete stack %s: %v", *stack.StackName, err)
+	}
+
+	fmt.Printf("Deleted stack %s\n", *stack.StackName)
+	return nil
+}
+
+func deleteStacksInParallel(svc api.Services, stacks []*cloudformation.Stack) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(stacks))
+
+	for _, stack := range stacks {
+		wg.Add(1)
+		go func(stack *cloudformation.Stack) {
+			defer wg.Done()
+			if err := deleteStack(svc, stack); err != nil {
+				errs <- err
+			}
+		}(stack)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	messages := []string{}
+	for err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, "\n"))
+	}
+
+	return nil
+}
+
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N] ", prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func ConfigureDeleteSchedule(app *kingpin.Application, svc api.Services) {
+	var cluster, projectName, service string
+	var deregisterTasks bool
+
+	cmd := app.Command("delete-schedule", "Delete a scheduled task")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "The name of the Compose project").
+		Short('p').
+		Default(currentDirName()).
+		StringVar(&projectName)
+
+	cmd.Flag("service", "The name of the scheduled compose service").
+		Short('s').
+		Required().
+		StringVar(&service)
+
+	cmd.Flag("deregister-tasks", "Deregister all task definitions in the schedule's task family").
+		BoolVar(&deregisterTasks)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		family := scheduleTaskFamily(projectName, service)
+		log.Printf("Deleting schedule %s on %s", family, cluster)
+		timer := time.Now()
+
+		stack, err := api.FindScheduleStack(svc.Cloudformation, cluster, family)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Deleting schedule cloudformation stack %s", *stack.StackName)
+		err = api.DeleteStackAndWait(svc.Cloudformation, stack, func(event *cloudformation.StackEvent) {
+			log.Printf("%s\n", api.FormatStackEvent(event))
+		})
+		if err != nil {
+			return err
+		}
+
+		if deregisterTasks {
+			log.Printf("Deregistering task definitions for %s", family)
+			err = api.DeregisterTaskFamily(svc.ECS, family, func(arn string) {
+				log.Printf("Deregistered task definition %s", arn)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Schedule %s deleted in %s", family, time.Now().Sub(timer).String())
+		return nil
+	})
+}
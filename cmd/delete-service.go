@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func ConfigureDeleteService(app *kingpin.Application, svc api.Services) {
+	var cluster, projectName string
+	var deregisterTasks bool
+
+	cmd := app.Command("delete-service", "Deletes an ECS service and its supporting infrastructure")
+	cmd.Flag("cluster", "The name of the ECS cluster the service runs on").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "The name of the Compose project").
+		Short('p').
+		Default(currentDirName()).
+		StringVar(&projectName)
+
+	cmd.Flag("deregister-tasks", "Deregister all task definitions in the service's task family").
+		BoolVar(&deregisterTasks)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		log.Printf("Deleting service %s on %s", projectName, cluster)
+		timer := time.Now()
+
+		serviceStack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
+		if err != nil {
+			return err
+		}
+		log.Printf("Found service stack %s", *serviceStack.StackName)
+
+		outputs := api.StackOutputMap(serviceStack)
+
+		// daemons can't be scaled, their tasks stop when the service is deleted
+		if stackParameterMap(serviceStack)["SchedulingStrategy"] != daemonSchedulingStrategy {
+			log.Printf("Scaling service %s to 0", outputs["ECSService"])
+			_, err = svc.ECS.UpdateService(&ecs.UpdateServiceInput{
+				Service:      aws.String(outputs["ECSService"]),
+				Cluster:      aws.String(outputs["ECSCluster"]),
+				DesiredCount: aws.Int64(0),
+			})
+			if err != nil {
+				return err
+			}
+
+			var printer = func(e *ecs.ServiceEvent) {
+				log.Println(*e.Message)
+			}
+
+			log.Printf("Waiting for tasks to drain")
+			err = api.PollUntilRunningCount(svc.ECS, outputs["ECSCluster"], outputs["ECSService"], 0, serviceScaleTimeout, printer)
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Deleting service cloudformation stack %s", *serviceStack.StackName)
+		err = api.DeleteStackAndWait(svc.Cloudformation, serviceStack, func(event *cloudformation.StackEvent) {
+			log.Printf("%s\n", api.FormatStackEvent(event))
+		})
+		if err != nil {
+			return err
+		}
+
+		if deregisterTasks {
+			log.Printf("Deregistering task definitions for %s", outputs["TaskFamily"])
+			err = api.DeregisterTaskFamily(svc.ECS, outputs["TaskFamily"], func(arn string) {
+				log.Printf("Deregistered task definition %s", arn)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Service %s deleted in %s", projectName, time.Now().Sub(timer).String())
+		return nil
+	})
+}
@@ -15,7 +15,7 @@ import (
 )
 
 func ConfigureDeploy(app *kingpin.Application, svc api.Services) {
-	var cluster, projectName, imageTags string
+	var cluster, projectName, imageTags, taskPolicy string
 	var composeFiles []string
 
 	cmd := app.Command("deploy", "Deploy updated task definitions to ECS")
@@ -33,6 +33,9 @@ func ConfigureDeploy(app *kingpin.Application, svc api.Services) {
 		Default("docker-compose.yml").
 		ExistingFilesVar(&composeFiles)
 
+	cmd.Flag("task-policy", "A file with an IAM policy for the service's task role, defaults to task-policy.json next to the compose file").
+		ExistingFileVar(&taskPolicy)
+
 	cmd.Arg("imagetags", "Tags in the form image=tag to apply to the task").
 		StringVar(&imageTags)
 
@@ -84,17 +87,22 @@ func ConfigureDeploy(app *kingpin.Application, svc api.Services) {
 			return err
 		}
 
-		resp, err := svc.ECS.RegisterTaskDefinition(taskDefinitionInput)
+		serviceStack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
 		if err != nil {
 			return err
 		}
-		log.Printf("Registered task definition %s:%d", *resp.TaskDefinition.Family, *resp.TaskDefinition.Revision)
+		log.Printf("Found service stack %s", *serviceStack.StackName)
 
-		serviceStack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
+		err = applyServiceTaskRole(svc, serviceStack, findTaskPolicyFile(taskPolicy, composeFiles), taskDefinitionInput)
 		if err != nil {
 			return err
 		}
-		log.Printf("Found service stack %s", *serviceStack.StackName)
+
+		resp, err := svc.ECS.RegisterTaskDefinition(taskDefinitionInput)
+		if err != nil {
+			return err
+		}
+		log.Printf("Registered task definition %s:%d", *resp.TaskDefinition.Family, *resp.TaskDefinition.Revision)
 
 		outputs := api.StackOutputMap(serviceStack)
 		timer := time.Now()
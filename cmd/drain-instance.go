@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// how long the service tasks on a draining instance have to move to other instances
+const instanceDrainTimeout = 30 * time.Minute
+
+func ConfigureDrainInstance(app *kingpin.Application, svc api.Services) {
+	var cluster, instanceID string
+	var terminate bool
+
+	cmd := app.Command("drain-instance", "Move the tasks off a container instance so it can be removed")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("terminate", "Terminate the instance once drained, the auto scaling group launches a replacement").
+		BoolVar(&terminate)
+
+	cmd.Arg("instance", "The EC2 instance id or container instance ARN to drain").
+		Required().
+		StringVar(&instanceID)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		timer := time.Now()
+
+		instance, err := drainInstance(svc, cluster, instanceID)
+		if err != nil {
+			return err
+		}
+
+		if terminate {
+			log.Printf("Terminating instance %s", *instance.Ec2InstanceId)
+			if err = api.TerminateInstance(svc.AutoScaling, *instance.Ec2InstanceId, false); err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Instance %s drained in %s", *instance.Ec2InstanceId, time.Now().Sub(timer).String())
+		return nil
+	})
+}
+
+// drainInstance sets a container instance to DRAINING and waits for its service tasks to stop,
+// tasks started with run-task keep running until the instance is terminated
+func drainInstance(svc api.Services, cluster, id string) (*ecs.ContainerInstance, error) {
+	instance, err := api.FindContainerInstance(svc.ECS, cluster, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if *instance.Status != api.ContainerInstanceStatusDraining {
+		log.Printf("Draining instance %s", *instance.Ec2InstanceId)
+		if err = api.DrainContainerInstance(svc.ECS, cluster, *instance.ContainerInstanceArn); err != nil {
+			return nil, err
+		}
+	}
+
+	err = api.PollUntilDrained(svc.ECS, cluster, *instance.ContainerInstanceArn, instanceDrainTimeout, func(count int) {
+		log.Printf("Instance %s has %d service tasks", *instance.Ec2InstanceId, count)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// healthCheckFlags are the load balancer health check flags shared by create-service
+// and update-service, values are kept as strings so unset flags can be detected
+type healthCheckFlags struct {
+	path                                 string
+	healthyThreshold, unhealthyThreshold string
+	interval, timeout                    string
+	deregistrationDelay, gracePeriod     string
+}
+
+func (f *healthCheckFlags) register(cmd *kingpin.CmdClause) {
+	cmd.Flag("healthcheck", "Path to check for HTTP health check").
+		StringVar(&f.path)
+
+	cmd.Flag("healthy-threshold", "The number of consecutive passing health checks before a task is healthy (2-10)").
+		StringVar(&f.healthyThreshold)
+
+	cmd.Flag("unhealthy-threshold", "The number of consecutive failed health checks before a task is unhealthy (2-10)").
+		StringVar(&f.unhealthyThreshold)
+
+	cmd.Flag("healthcheck-interval", "The seconds between health checks of a task (5-300)").
+		StringVar(&f.interval)
+
+	cmd.Flag("healthcheck-timeout", "The seconds to wait for a health check response, less than the interval (2-120)").
+		StringVar(&f.timeout)
+
+	cmd.Flag("deregistration-delay", "The seconds to let requests drain from a task before it's stopped (0-3600)").
+		StringVar(&f.deregistrationDelay)
+
+	cmd.Flag("grace-period", "The seconds to ignore failed health checks after a task starts, for slow booting apps").
+		StringVar(&f.gracePeriod)
+}
+
+// params resolves the flags against the previous stack parameters into service
+// stack parameters, flags that weren't provided keep their previous values
+func (f *healthCheckFlags) params(previous map[string]string) (map[string]string, error) {
+	path := f.path
+	if path == "" {
+		path = previous["HealthCheckUrl"]
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	bounded := func(name, flag, param, fallback string, min, max int64) (int64, error) {
+		i, err := resolveIntFlag(previous, name, flag, param, fallback)
+		if err != nil {
+			return 0, err
+		}
+		if i < min || i > max {
+			return 0, fmt.Errorf("--%s must be between %d and %d", name, min, max)
+		}
+		return i, nil
+	}
+
+	healthy, err := bounded("healthy-threshold", f.healthyThreshold, "HealthyThreshold", "2", 2, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	unhealthy, err := bounded("unhealthy-threshold", f.unhealthyThreshold, "UnhealthyThreshold", "10", 2, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := bounded("healthcheck-interval", f.interval, "HealthCheckInterval", "30", 5, 300)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := bounded("healthcheck-timeout", f.timeout, "HealthCheckTimeout", "5", 2, 120)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout >= interval {
+		return nil, fmt.Errorf("The health check timeout %ds must be less than the interval %ds", timeout, interval)
+	}
+
+	delay, err := bounded("deregistration-delay", f.deregistrationDelay, "DeregistrationDelay", "60", 0, 3600)
+	if err != nil {
+		return nil, err
+	}
+
+	grace, err := bounded("grace-period", f.gracePeriod, "HealthCheckGracePeriod", "0", 0, 2147483647)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"HealthCheckUrl":         path,
+		"HealthyThreshold":       strconv.FormatInt(healthy, 10),
+		"UnhealthyThreshold":     strconv.FormatInt(unhealthy, 10),
+		"HealthCheckInterval":    strconv.FormatInt(interval, 10),
+		"HealthCheckTimeout":     strconv.FormatInt(timeout, 10),
+		"DeregistrationDelay":    strconv.FormatInt(delay, 10),
+		"HealthCheckGracePeriod": strconv.FormatInt(grace, 10),
+	}, nil
+}
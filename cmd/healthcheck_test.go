@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHealthCheckFlagsParams(t *testing.T) {
+	for _, tc := range []struct {
+		flags    healthCheckFlags
+		previous map[string]string
+		expected map[string]string
+	}{
+		{
+			healthCheckFlags{},
+			map[string]string{},
+			map[string]string{"HealthCheckUrl": "/", "HealthyThreshold": "2", "UnhealthyThreshold": "10",
+				"HealthCheckInterval": "30", "HealthCheckTimeout": "5", "DeregistrationDelay": "60", "HealthCheckGracePeriod": "0"},
+		},
+		{
+			healthCheckFlags{path: "/health", interval: "10", gracePeriod: "120"},
+			map[string]string{"HealthCheckUrl": "/", "HealthyThreshold": "3", "DeregistrationDelay": "30"},
+			map[string]string{"HealthCheckUrl": "/health", "HealthyThreshold": "3", "UnhealthyThreshold": "10",
+				"HealthCheckInterval": "10", "HealthCheckTimeout": "5", "DeregistrationDelay": "30", "HealthCheckGracePeriod": "120"},
+		},
+		{
+			healthCheckFlags{deregistrationDelay: "0"},
+			map[string]string{"HealthCheckUrl": "/ping"},
+			map[string]string{"HealthCheckUrl": "/ping", "HealthyThreshold": "2", "UnhealthyThreshold": "10",
+				"HealthCheckInterval": "30", "HealthCheckTimeout": "5", "DeregistrationDelay": "0", "HealthCheckGracePeriod": "0"},
+		},
+	} {
+		actual, err := tc.flags.params(tc.previous)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc.flags, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.flags, tc.expected, actual)
+		}
+	}
+}
+
+func TestHealthCheckFlagsParamsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		flags    healthCheckFlags
+		previous map[string]string
+	}{
+		{healthCheckFlags{healthyThreshold: "1"}, map[string]string{}},
+		{healthCheckFlags{unhealthyThreshold: "11"}, map[string]string{}},
+		{healthCheckFlags{interval: "301"}, map[string]string{}},
+		{healthCheckFlags{timeout: "abc"}, map[string]string{}},
+		{healthCheckFlags{interval: "10", timeout: "10"}, map[string]string{}},
+		{healthCheckFlags{interval: "5"}, map[string]string{"HealthCheckTimeout": "5"}},
+		{healthCheckFlags{deregistrationDelay: "3601"}, map[string]string{}},
+	} {
+		if _, err := tc.flags.params(tc.previous); err == nil {
+			t.Errorf("%+v: expected an error", tc.flags)
+		}
+	}
+}
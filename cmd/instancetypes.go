@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lox/ecsy/templates"
+)
+
+// instanceType is an entry in the catalogue embedded from templates/src/instance-types.json,
+// which is regenerated with scripts/update-instance-types.sh
+type instanceType struct {
+	Name      string `json:"-"`
+	VCPUs     int64  `json:"vcpus"`
+	MemoryMiB int64  `json:"memoryMiB"`
+	Arch      string `json:"arch"`
+}
+
+func loadInstanceTypes() (map[string]instanceType, error) {
+	catalogue := map[string]instanceType{}
+	if err := json.Unmarshal([]byte(templates.InstanceTypes()), &catalogue); err != nil {
+		return nil, fmt.Errorf("Failed to parse the instance type catalogue: %v", err)
+	}
+	return catalogue, nil
+}
+
+// lookupInstanceType finds an instance type in the catalogue, unknown types suggest
+// the other sizes in the same family
+func lookupInstanceType(name string) (instanceType, error) {
+	catalogue, err := loadInstanceTypes()
+	if err != nil {
+		return instanceType{}, err
+	}
+
+	if t, ok := catalogue[name]; ok {
+		t.Name = name
+		return t, nil
+	}
+
+	family := strings.SplitN(name, ".", 2)[0]
+	sizes := []string{}
+	for known := range catalogue {
+		if strings.HasPrefix(known, family+".") {
+			sizes = append(sizes, known)
+		}
+	}
+
+	if len(sizes) == 0 {
+		return instanceType{}, fmt.Errorf("Unknown instance type %q", name)
+	}
+
+	sort.Slice(sizes, func(i, j int) bool {
+		a, b := catalogue[sizes[i]], catalogue[sizes[j]]
+		if a.VCPUs != b.VCPUs {
+			return a.VCPUs < b.VCPUs
+		}
+		return a.MemoryMiB < b.MemoryMiB
+	})
+	return instanceType{}, fmt.Errorf("Unknown instance type %q, %s comes in %s",
+		name, family, strings.Join(sizes, ", "))
+}
+
+// lookupInstanceTypes finds several instance types, which have to share an architecture
+// as instances are launched from a single AMI
+func lookupInstanceTypes(names []string) ([]instanceType, error) {
+	types := []instanceType{}
+	for _, name := range names {
+		t, err := lookupInstanceType(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(types) > 0 && t.Arch != types[0].Arch {
+			return nil, fmt.Errorf("Instance types can't mix architectures, %s is %s and %s is %s",
+				types[0].Name, types[0].Arch, t.Name, t.Arch)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// instanceCapacityRange returns the least and most vCPUs and memory of any of the instance types
+func instanceCapacityRange(types []instanceType) (smallest, largest instanceType) {
+	smallest, largest = types[0], types[0]
+	for _, t := range types[1:] {
+		if t.VCPUs < smallest.VCPUs {
+			smallest.VCPUs = t.VCPUs
+		}
+		if t.MemoryMiB < smallest.MemoryMiB {
+			smallest.MemoryMiB = t.MemoryMiB
+		}
+		if t.VCPUs > largest.VCPUs {
+			largest.VCPUs = t.VCPUs
+		}
+		if t.MemoryMiB > largest.MemoryMiB {
+			largest.MemoryMiB = t.MemoryMiB
+		}
+	}
+	return smallest, largest
+}
+
+// instanceTypeParams returns the cluster stack parameters for the instance types, clearing
+// the slots of any types that aren't used
+func instanceTypeParams(names []string) map[string]string {
+	params := map[string]string{"InstanceType": names[0]}
+	for idx := 1; idx < maxInstanceTypes; idx++ {
+		val := ""
+		if idx < len(names) {
+			val = names[idx]
+		}
+		params["InstanceType"+strconv.Itoa(idx+1)] = val
+	}
+	return params
+}
+
+// MemoryGiB returns the memory of the instance type in GiB
+func (t instanceType) MemoryGiB() float64 {
+	return float64(t.MemoryMiB) / 1024
+}
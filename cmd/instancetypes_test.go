@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupInstanceType(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		vcpus     int64
+		memoryMiB int64
+		arch      string
+	}{
+		{"t3.medium", 2, 4096, "x86_64"},
+		{"m7g.large", 2, 8192, "arm64"},
+	} {
+		actual, err := lookupInstanceType(tc.name)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if actual.Name != tc.name || actual.VCPUs != tc.vcpus || actual.MemoryMiB != tc.memoryMiB || actual.Arch != tc.arch {
+			t.Errorf("%s: expected %d vCPUs, %d MiB and %s, got %+v", tc.name, tc.vcpus, tc.memoryMiB, tc.arch, actual)
+		}
+	}
+}
+
+func TestLookupInstanceTypeErrors(t *testing.T) {
+	for name, expected := range map[string]string{
+		"t3.enormous": "t3 comes in t3.nano, t3.micro",
+		"zz9.large":   `Unknown instance type "zz9.large"`,
+	} {
+		_, err := lookupInstanceType(name)
+		if err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected an error containing %q, got %q", name, expected, err.Error())
+		}
+	}
+}
+
+func TestLookupInstanceTypesMixedArchitectures(t *testing.T) {
+	if _, err := lookupInstanceTypes([]string{"t3.medium", "m7g.large"}); err == nil {
+		t.Errorf("Expected an error for x86_64 and arm64 instance types")
+	}
+	if types, err := lookupInstanceTypes([]string{"m7g.large", "c7g.large"}); err != nil || len(types) != 2 {
+		t.Errorf("Expected two arm64 instance types, got %v (%v)", types, err)
+	}
+}
+
+func TestInstanceTypeParams(t *testing.T) {
+	for _, tc := range []struct {
+		names    []string
+		expected map[string]string
+	}{
+		{
+			[]string{"t3.medium"},
+			map[string]string{"InstanceType": "t3.medium", "InstanceType2": "", "InstanceType3": "", "InstanceType4": ""},
+		},
+		{
+			[]string{"m5.large", "m5a.large", "m6i.large"},
+			map[string]string{"InstanceType": "m5.large", "InstanceType2": "m5a.large", "InstanceType3": "m6i.large", "InstanceType4": ""},
+		},
+	} {
+		actual := instanceTypeParams(tc.names)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%v: expected %v, got %v", tc.names, tc.expected, actual)
+		}
+	}
+}
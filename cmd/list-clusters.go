@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type clusterListing struct {
+	Name            string   `json:"name"`
+	Stack           string   `json:"stack"`
+	Status          string   `json:"status"`
+	InstanceType    string   `json:"instanceType"`
+	DesiredCapacity string   `json:"desiredCapacity"`
+	NetworkStack    string   `json:"networkStack"`
+	Services        []string `json:"services"`
+}
+
+func ConfigureListClusters(app *kingpin.Application, svc api.Services) {
+	var format string
+
+	cmd := app.Command("list-clusters", "List the ECS clusters managed by ecsy")
+	cmd.Flag("format", "The output format, either table or json").
+		Default("table").
+		EnumVar(&format, "table", "json")
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		clusterStacks, err := api.FindClusterStacks(svc.Cloudformation)
+		if err != nil {
+			return err
+		}
+
+		serviceStacks, err := api.FindServiceStacks(svc.Cloudformation, "")
+		if err != nil {
+			return err
+		}
+
+		networkStacks, err := api.FindNetworkStacks(svc.Cloudformation)
+		if err != nil {
+			return err
+		}
+
+		// the capacity provider changes the desired capacity, so it's read from the groups
+		groupNames := []string{}
+		for _, stack := range clusterStacks {
+			if group, ok := api.GetStackOutputByKey(stack, "AutoScalingGroup"); ok {
+				groupNames = append(groupNames, group)
+			}
+		}
+
+		groups, err := api.DescribeAutoScalingGroups(svc.AutoScaling, groupNames)
+		if err != nil {
+			return err
+		}
+
+		services := map[string][]string{}
+		for _, stack := range serviceStacks {
+			outputs := api.StackOutputMap(stack)
+			services[outputs["ECSCluster"]] = append(services[outputs["ECSCluster"]], outputs["TaskFamily"])
+		}
+
+		clusters := []clusterListing{}
+		for _, stack := range clusterStacks {
+			name, _ := api.GetStackOutputByKey(stack, "ECSCluster")
+			instanceType, _ := api.GetStackParameterByKey(stack, "InstanceType")
+			capacity, _ := api.GetStackParameterByKey(stack, "DesiredCapacity")
+			if groupName, ok := api.GetStackOutputByKey(stack, "AutoScalingGroup"); ok {
+				if group, ok := groups[groupName]; ok {
+					capacity = strconv.FormatInt(*group.DesiredCapacity, 10)
+				}
+			}
+
+			listing := clusterListing{
+				Name:            name,
+				Stack:           *stack.StackName,
+				Status:          *stack.StackStatus,
+				InstanceType:    instanceType,
+				DesiredCapacity: capacity,
+				Services:        services[name],
+			}
+
+			if network, ok := networkStacks[name]; ok {
+				listing.NetworkStack = *network.StackName
+			}
+
+			sort.Strings(listing.Services)
+			clusters = append(clusters, listing)
+		}
+
+		sort.Slice(clusters, func(i, j int) bool {
+			return clusters[i].Name < clusters[j].Name
+		})
+
+		if format == "json" {
+			return printJSON(clusters)
+		}
+
+		rows := [][]string{}
+		for _, cluster := range clusters {
+			rows = append(rows, []string{
+				cluster.Name,
+				cluster.Status,
+				cluster.InstanceType,
+				cluster.DesiredCapacity,
+				valueOrDash(cluster.NetworkStack),
+				valueOrDash(strings.Join(cluster.Services, ",")),
+			})
+		}
+
+		return printTable([]string{"CLUSTER", "STATUS", "INSTANCE TYPE", "CAPACITY", "NETWORK", "SERVICES"}, rows)
+	})
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printTable(headers []string, rows [][]string) error {
+	return writeTable(os.Stdout, headers, rows)
+}
+
+func writeTable(out io.Writer, headers []string, rows [][]string) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type scheduleListing struct {
+	TaskFamily string `json:"taskFamily"`
+	Schedule   string `json:"schedule"`
+	Stack      string `json:"stack"`
+	Status     string `json:"status"`
+	Revision   string `json:"revision"`
+}
+
+func ConfigureListSchedules(app *kingpin.Application, svc api.Services) {
+	var cluster, format string
+
+	cmd := app.Command("list-schedules", "List the scheduled tasks in a cluster")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("format", "The output format, either table or json").
+		Default("table").
+		EnumVar(&format, "table", "json")
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		stacks, err := api.FindScheduleStacks(svc.Cloudformation, cluster)
+		if err != nil {
+			return err
+		}
+
+		schedules := []scheduleListing{}
+		for _, stack := range stacks {
+			outputs := api.StackOutputMap(stack)
+			taskDefinition, _ := api.GetStackParameterByKey(stack, "TaskDefinition")
+
+			schedules = append(schedules, scheduleListing{
+				TaskFamily: outputs["TaskFamily"],
+				Schedule:   outputs["ScheduleExpression"],
+				Stack:      *stack.StackName,
+				Status:     *stack.StackStatus,
+				Revision:   api.TaskDefinitionRevision(taskDefinition),
+			})
+		}
+
+		sort.Slice(schedules, func(i, j int) bool {
+			return schedules[i].TaskFamily < schedules[j].TaskFamily
+		})
+
+		if format == "json" {
+			return printJSON(schedules)
+		}
+
+		rows := [][]string{}
+		for _, schedule := range schedules {
+			rows = append(rows, []string{
+				schedule.TaskFamily,
+				schedule.Schedule,
+				schedule.Revision,
+				schedule.Status,
+			})
+		}
+
+		return printTable([]string{"TASK", "SCHEDULE", "REVISION", "STATUS"}, rows)
+	})
+}
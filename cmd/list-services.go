@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type serviceListing struct {
+	Cluster      string `json:"cluster"`
+	Service      string `json:"service"`
+	Stack        string `json:"stack"`
+	TaskFamily   string `json:"taskFamily"`
+	Revision     string `json:"revision"`
+	DesiredCount int64  `json:"desiredCount"`
+	RunningCount int64  `json:"runningCount"`
+	LoadBalancer string `json:"loadBalancer"`
+}
+
+func ConfigureListServices(app *kingpin.Application, svc api.Services) {
+	var cluster, format string
+
+	cmd := app.Command("list-services", "List the ECS services managed by ecsy")
+	cmd.Flag("cluster", "Only list services in this ECS cluster").
+		StringVar(&cluster)
+
+	cmd.Flag("format", "The output format, either table or json").
+		Default("table").
+		EnumVar(&format, "table", "json")
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		stacks, err := api.FindServiceStacks(svc.Cloudformation, cluster)
+		if err != nil {
+			return err
+		}
+
+		byCluster := map[string][]string{}
+		listings := []serviceListing{}
+
+		for _, stack := range stacks {
+			outputs := api.StackOutputMap(stack)
+			listings = append(listings, serviceListing{
+				Cluster:      outputs["ECSCluster"],
+				Service:      outputs["ECSService"],
+				Stack:        *stack.StackName,
+				TaskFamily:   outputs["TaskFamily"],
+				LoadBalancer: outputs["ECSLoadBalancer"],
+			})
+			byCluster[outputs["ECSCluster"]] = append(byCluster[outputs["ECSCluster"]], outputs["ECSService"])
+		}
+
+		for clusterName, serviceNames := range byCluster {
+			services, err := api.DescribeServices(svc.ECS, clusterName, serviceNames)
+			if err != nil {
+				return err
+			}
+
+			for idx, listing := range listings {
+				if service, ok := services[listing.Service]; ok && listing.Cluster == clusterName {
+					listings[idx].Revision = api.TaskDefinitionRevision(*service.TaskDefinition)
+					listings[idx].DesiredCount = *service.DesiredCount
+					listings[idx].RunningCount = *service.RunningCount
+				}
+			}
+		}
+
+		sort.Slice(listings, func(i, j int) bool {
+			if listings[i].Cluster != listings[j].Cluster {
+				return listings[i].Cluster < listings[j].Cluster
+			}
+			return listings[i].TaskFamily < listings[j].TaskFamily
+		})
+
+		if format == "json" {
+			return printJSON(listings)
+		}
+
+		rows := [][]string{}
+		for _, listing := range listings {
+			rows = append(rows, []string{
+				listing.Cluster,
+				listing.TaskFamily,
+				valueOrDash(listing.Revision),
+				strconv.FormatInt(listing.DesiredCount, 10),
+				strconv.FormatInt(listing.RunningCount, 10),
+				valueOrDash(listing.LoadBalancer),
+			})
+		}
+
+		return printTable([]string{"CLUSTER", "TASK FAMILY", "REVISION", "DESIRED", "RUNNING", "LOAD BALANCER"}, rows)
+	})
+}
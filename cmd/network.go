@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	// the shared load balancer and the auto scaling group need subnets in this many zones
+	minSubnetZones = 2
+
+	// the network template has subnets for this many zones
+	maxNetworkZones = 4
+
+	defaultVpcCidr = "10.0.0.0/16"
+)
+
+// vpcFlags select an existing VPC and subnets for a cluster instead of creating a network stack
+type vpcFlags struct {
+	vpcID                             string
+	privateSubnets, publicSubnets     []string
+	privateSubnetTag, publicSubnetTag string
+}
+
+func (f *vpcFlags) register(cmd *kingpin.CmdClause) {
+	cmd.Flag("vpc-id", "An existing VPC to run the cluster in instead of creating a network stack").
+		StringVar(&f.vpcID)
+
+	cmd.Flag("private-subnet", "A private subnet in the --vpc-id for instances. Can be repeated").
+		StringsVar(&f.privateSubnets)
+
+	cmd.Flag("public-subnet", "A public subnet in the --vpc-id for the shared load balancer. Can be repeated").
+		StringsVar(&f.publicSubnets)
+
+	cmd.Flag("private-subnet-tag", "Use the subnets in the --vpc-id with a tag as private subnets, either Key or Key=Value").
+		StringVar(&f.privateSubnetTag)
+
+	cmd.Flag("public-subnet-tag", "Use the subnets in the --vpc-id with a tag as public subnets, either Key or Key=Value").
+		StringVar(&f.publicSubnetTag)
+}
+
+func (f *vpcFlags) isSet() bool {
+	return f.vpcID != "" || len(f.privateSubnets) > 0 || len(f.publicSubnets) > 0 ||
+		f.privateSubnetTag != "" || f.publicSubnetTag != ""
+}
+
+// subnets returns the private and public subnets to use in the VPC, either given by id
+// or found by their tags
+func (f *vpcFlags) subnets(svc api.Services) ([]string, []string, error) {
+	if f.vpcID == "" {
+		return nil, nil, fmt.Errorf("Subnets can only be given along with a --vpc-id")
+	}
+
+	private, err := f.lookupSubnets(svc, "private", f.privateSubnets, f.privateSubnetTag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	public, err := f.lookupSubnets(svc, "public", f.publicSubnets, f.publicSubnetTag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return private, public, nil
+}
+
+// cidr returns the primary CIDR block of the VPC, which instances accept traffic from
+func (f *vpcFlags) cidr(svc api.Services) (string, error) {
+	vpc, err := api.DescribeVpc(svc.EC2, f.vpcID)
+	if err != nil {
+		return "", err
+	}
+	return *vpc.CidrBlock, nil
+}
+
+func (f *vpcFlags) lookupSubnets(svc api.Services, kind string, ids []string, tag string) ([]string, error) {
+	var subnets []*ec2.Subnet
+	var err error
+
+	switch {
+	case len(ids) > 0 && tag != "":
+		return nil, fmt.Errorf("Use either --%s-subnet or --%s-subnet-tag, not both", kind, kind)
+	case len(ids) > 0:
+		subnets, err = api.DescribeSubnets(svc.EC2, f.vpcID, ids)
+	case tag != "":
+		parts := strings.SplitN(tag, "=", 2)
+		value := ""
+		if len(parts) > 1 {
+			value = parts[1]
+		}
+		subnets, err = api.FindSubnetsByTag(svc.EC2, f.vpcID, parts[0], value)
+	default:
+		return nil, fmt.Errorf("A --vpc-id needs either --%s-subnet or --%s-subnet-tag", kind, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]bool{}
+	result := []string{}
+	for _, subnet := range subnets {
+		zones[*subnet.AvailabilityZone] = true
+		result = append(result, *subnet.SubnetId)
+		log.Printf("Using %s subnet %s in %s", kind, *subnet.SubnetId, *subnet.AvailabilityZone)
+	}
+
+	if len(zones) < minSubnetZones {
+		return nil, fmt.Errorf("The %s subnets need to be in at least %d availability zones", kind, minSubnetZones)
+	}
+
+	return result, nil
+}
+
+// networkFlags configure the VPC of a new network stack
+type networkFlags struct {
+	vpcCidr, natGateways string
+	zones                int
+}
+
+func (f *networkFlags) register(cmd *kingpin.CmdClause) {
+	cmd.Flag("vpc-cidr", "The CIDR block of a new VPC, between a /16 and a /24. Use a different block for each cluster to peer them. Defaults to "+defaultVpcCidr).
+		StringVar(&f.vpcCidr)
+
+	cmd.Flag("zones", "The number of availability zones for a new VPC to have subnets in. Defaults to "+strconv.Itoa(minSubnetZones)).
+		IntVar(&f.zones)
+
+	cmd.Flag("nat-gateways", "Whether a new VPC has a single shared NAT gateway or one in each availability zone. Defaults to single").
+		EnumVar(&f.natGateways, "per-zone", "single")
+}
+
+func (f *networkFlags) isSet() bool {
+	return f.vpcCidr != "" || f.zones != 0 || f.natGateways != ""
+}
+
+// params returns the network stack parameters, the VPC's block is split into eight subnets
+// so that a public and a private subnet fit in each of up to four zones
+func (f *networkFlags) params(svc api.Services) (map[string]string, error) {
+	cidr := f.vpcCidr
+	if cidr == "" {
+		cidr = defaultVpcCidr
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid --vpc-cidr %q: %v", cidr, err)
+	} else if network.String() != cidr {
+		return nil, fmt.Errorf("The --vpc-cidr %s has host bits set, did you mean %s?", cidr, network.String())
+	}
+
+	prefix, bits := network.Mask.Size()
+	if bits != 32 || prefix < 16 || prefix > 24 {
+		return nil, fmt.Errorf("The --vpc-cidr must be an IPv4 block between a /16 and a /24")
+	}
+
+	zones := f.zones
+	if zones == 0 {
+		zones = minSubnetZones
+	}
+
+	if zones < minSubnetZones || zones > maxNetworkZones {
+		return nil, fmt.Errorf("A VPC can have subnets in %d to %d availability zones", minSubnetZones, maxNetworkZones)
+	}
+
+	available, err := api.ListAvailabilityZones(svc.EC2)
+	if err != nil {
+		return nil, err
+	} else if len(available) < zones {
+		return nil, fmt.Errorf("The region only has %d availability zones", len(available))
+	}
+
+	natGateways := "Single"
+	if f.natGateways == "per-zone" {
+		natGateways = "PerZone"
+	}
+
+	return map[string]string{
+		"VpcCidr":           cidr,
+		"SubnetBits":        strconv.Itoa(32 - prefix - 3),
+		"AvailabilityZones": strconv.Itoa(zones),
+		"NatGateways":       natGateways,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/lox/ecsy/api"
+)
+
+// fakeEC2 has the given availability zones and no subnets or VPCs
+type fakeEC2 struct {
+	zones []string
+}
+
+func (f fakeEC2) DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{}, nil
+}
+
+func (f fakeEC2) DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	output := &ec2.DescribeAvailabilityZonesOutput{}
+	for _, zone := range f.zones {
+		output.AvailabilityZones = append(output.AvailabilityZones, &ec2.AvailabilityZone{ZoneName: aws.String(zone)})
+	}
+	return output, nil
+}
+
+func (f fakeEC2) DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	return &ec2.DescribeVpcsOutput{}, nil
+}
+
+func TestNetworkFlagsParams(t *testing.T) {
+	svc := api.Services{
+		EC2: fakeEC2{zones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+	}
+
+	for _, tc := range []struct {
+		flags    networkFlags
+		expected map[string]string
+	}{
+		{
+			networkFlags{},
+			map[string]string{"VpcCidr": "10.0.0.0/16", "SubnetBits": "13", "AvailabilityZones": "2", "NatGateways": "Single"},
+		},
+		{
+			networkFlags{vpcCidr: "10.20.0.0/20", zones: 3, natGateways: "per-zone"},
+			map[string]string{"VpcCidr": "10.20.0.0/20", "SubnetBits": "9", "AvailabilityZones": "3", "NatGateways": "PerZone"},
+		},
+		{
+			networkFlags{vpcCidr: "192.168.1.0/24", natGateways: "single"},
+			map[string]string{"VpcCidr": "192.168.1.0/24", "SubnetBits": "5", "AvailabilityZones": "2", "NatGateways": "Single"},
+		},
+	} {
+		actual, err := tc.flags.params(svc)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc.flags, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.flags, tc.expected, actual)
+		}
+	}
+}
+
+func TestNetworkFlagsParamsErrors(t *testing.T) {
+	svc := api.Services{
+		EC2: fakeEC2{zones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+	}
+
+	for _, flags := range []networkFlags{
+		{vpcCidr: "10.0.0.0"},
+		{vpcCidr: "10.0.1.0/16"},
+		{vpcCidr: "10.0.0.0/8"},
+		{vpcCidr: "10.0.0.0/25"},
+		{vpcCidr: "fd00::/56"},
+		{zones: 1},
+		{zones: 5},
+		{zones: 4},
+	} {
+		if _, err := flags.params(svc); err == nil {
+			t.Errorf("%+v: expected an error", flags)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// the service template supports this many strategies and constraints
+const maxServicePlacements = 2
+
+// services spread over availability zones and then instances unless told otherwise
+var defaultServicePlacementStrategies = []string{"spread:az", "spread:instance"}
+
+var placementFieldAliases = map[string]string{
+	"az":       "attribute:ecs.availability-zone",
+	"instance": "instanceId",
+}
+
+// placementFlags are the task placement flags shared by create-service and run-task
+type placementFlags struct {
+	strategies, constraints []string
+}
+
+func (f *placementFlags) register(cmd *kingpin.CmdClause) {
+	cmd.Flag("placement-strategy", "How to place tasks, e.g spread:az, spread:instance, binpack:memory or random. Can be repeated").
+		StringsVar(&f.strategies)
+
+	cmd.Flag("placement-constraint", "Where tasks can be placed, either distinctInstance or an expression like \"attribute:ecs.instance-type =~ t2.*\". Can be repeated").
+		StringsVar(&f.constraints)
+}
+
+func (f *placementFlags) placementStrategies() ([]*api.PlacementStrategy, error) {
+	strategies := []*api.PlacementStrategy{}
+
+	for _, s := range f.strategies {
+		parts := strings.SplitN(s, ":", 2)
+		strategy := &api.PlacementStrategy{Type: aws.String(parts[0])}
+
+		field := ""
+		if len(parts) > 1 {
+			field = parts[1]
+			if alias, ok := placementFieldAliases[field]; ok {
+				field = alias
+			}
+		}
+
+		switch parts[0] {
+		case api.PlacementStrategyTypeSpread:
+			if field == "" {
+				return nil, fmt.Errorf("Placement strategy %q needs a field to spread by, e.g spread:az", s)
+			}
+		case api.PlacementStrategyTypeBinpack:
+			if field != "cpu" && field != "memory" {
+				return nil, fmt.Errorf("Placement strategy %q can only binpack by cpu or memory", s)
+			}
+		case api.PlacementStrategyTypeRandom:
+			if field != "" {
+				return nil, fmt.Errorf("Placement strategy %q doesn't take a field", s)
+			}
+		default:
+			return nil, fmt.Errorf("Unknown placement strategy %q, expected spread, binpack or random", s)
+		}
+
+		if field != "" {
+			strategy.Field = aws.String(field)
+		}
+		strategies = append(strategies, strategy)
+	}
+
+	return strategies, nil
+}
+
+func (f *placementFlags) placementConstraints() []*api.PlacementConstraint {
+	constraints := []*api.PlacementConstraint{}
+
+	for _, c := range f.constraints {
+		if c == api.PlacementConstraintTypeDistinctInstance {
+			constraints = append(constraints, &api.PlacementConstraint{
+				Type: aws.String(api.PlacementConstraintTypeDistinctInstance),
+			})
+			continue
+		}
+
+		constraints = append(constraints, &api.PlacementConstraint{
+			Type:       aws.String(api.PlacementConstraintTypeMemberOf),
+			Expression: aws.String(c),
+		})
+	}
+
+	return constraints
+}
+
+// params returns the service stack parameters for the placement, using the default
+// strategies if none were given. Daemons run on every instance so only take constraints.
+func (f *placementFlags) params(daemon bool) (map[string]string, error) {
+	if daemon && len(f.strategies) > 0 {
+		return nil, fmt.Errorf("Daemon services run on every instance and don't support placement strategies")
+	} else if len(f.strategies) == 0 && !daemon {
+		f.strategies = defaultServicePlacementStrategies
+	}
+
+	if len(f.strategies) > maxServicePlacements || len(f.constraints) > maxServicePlacements {
+		return nil, fmt.Errorf("Services support at most %d placement strategies and %d constraints",
+			maxServicePlacements, maxServicePlacements)
+	}
+
+	strategies, err := f.placementStrategies()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{}
+	for idx, strategy := range strategies {
+		suffix := strconv.Itoa(idx + 1)
+		params["PlacementStrategy"+suffix+"Type"] = *strategy.Type
+		params["PlacementStrategy"+suffix+"Field"] = aws.StringValue(strategy.Field)
+	}
+
+	for idx, constraint := range f.placementConstraints() {
+		suffix := strconv.Itoa(idx + 1)
+		params["PlacementConstraint"+suffix+"Type"] = *constraint.Type
+		params["PlacementConstraint"+suffix+"Expression"] = aws.StringValue(constraint.Expression)
+	}
+
+	return params, nil
+}
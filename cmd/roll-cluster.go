@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/lox/ecsy/api"
+	"github.com/lox/ecsy/templates"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// how long replacement instances have to register with the cluster
+const instanceRegisterTimeout = 15 * time.Minute
+
+func ConfigureRollCluster(app *kingpin.Application, svc api.Services) {
+	var cluster, ami string
+	var instanceTypes, publicSubnets []string
+	var batchSize int
+
+	cmd := app.Command("roll-cluster", "Replace the cluster's instances that don't use its current AMI and instance types")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("ami", "Change the AMI, either al2, al2023, an SSM parameter path or an AMI ID to pin").
+		StringVar(&ami)
+
+	cmd.Flag("type", "Change the EC2 instance types. Can be repeated").
+		StringsVar(&instanceTypes)
+
+	cmd.Flag("public-subnet", "A public subnet for the shared load balancer, only needed by clusters created before it existed that aren't in a network stack. Can be repeated").
+		StringsVar(&publicSubnets)
+
+	cmd.Flag("batch-size", "The number of instances to replace at a time").
+		Default("1").
+		IntVar(&batchSize)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		if batchSize < 1 {
+			return fmt.Errorf("The batch size must be at least 1")
+		}
+
+		stack, err := api.FindClusterStack(svc.Cloudformation, cluster)
+		if err != nil {
+			return err
+		} else if stack == nil {
+			return fmt.Errorf("No cluster exists for %q. Use `create-cluster`", cluster)
+		}
+
+		timer := time.Now()
+
+		// updating the stack also resolves the latest AMI from its SSM parameter
+		params, err := rollClusterParams(svc, stack, ami, instanceTypes, publicSubnets)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Updating cluster cloudformation stack %s", *stack.StackName)
+		err = api.UpdateStackAndWait(svc.Cloudformation, stack, templates.EcsStack(), api.UpdateStackContext{
+			Params: params,
+		}, func(event *cloudformation.StackEvent) {
+			log.Printf("%s\n", api.FormatStackEvent(event))
+		})
+		if err == api.ErrNoStackUpdates {
+			log.Printf("Cluster stack is already up to date")
+		} else if err != nil {
+			return err
+		}
+
+		stack, err = api.FindClusterStack(svc.Cloudformation, cluster)
+		if err != nil {
+			return err
+		}
+
+		outputs := api.StackOutputMap(stack)
+		if err = outputs.RequireKeys("AutoScalingGroup", "ImageId"); err != nil {
+			return err
+		}
+
+		current := stackParameterMap(stack)
+		wantedTypes := map[string]bool{}
+		for idx := 0; idx < maxInstanceTypes; idx++ {
+			key := "InstanceType"
+			if idx > 0 {
+				key += strconv.Itoa(idx + 1)
+			}
+			if current[key] != "" {
+				wantedTypes[current[key]] = true
+			}
+		}
+
+		// managed scaling would otherwise scale in the replacements before tasks move to them
+		log.Printf("Suspending scaling of %s during the roll", outputs["AutoScalingGroup"])
+		if err = api.SuspendScaling(svc.AutoScaling, outputs["AutoScalingGroup"]); err != nil {
+			return err
+		}
+		defer func() {
+			log.Printf("Resuming scaling of %s", outputs["AutoScalingGroup"])
+			if err := api.ResumeScaling(svc.AutoScaling, outputs["AutoScalingGroup"]); err != nil {
+				log.Printf("Failed to resume scaling of %s: %v", outputs["AutoScalingGroup"], err)
+			}
+		}()
+
+		replaced := 0
+
+		// instances are checked again after each batch, so an interrupted roll carries on
+		// from where it stopped when run again
+		for {
+			outdated, upToDate, err := findOutdatedInstances(svc, cluster, outputs["ImageId"], wantedTypes)
+			if err != nil {
+				return err
+			}
+
+			if len(outdated) == 0 {
+				break
+			}
+
+			batch := outdated
+			if len(batch) > batchSize {
+				batch = batch[:batchSize]
+			}
+
+			log.Printf("Replacing %d of %d outdated instances", len(batch), len(outdated))
+			err = replaceInstances(svc, cluster, outputs["AutoScalingGroup"], outputs["ImageId"], wantedTypes, batch, len(outdated)+upToDate, upToDate)
+			if err != nil {
+				return err
+			}
+
+			replaced += len(batch)
+		}
+
+		log.Printf("Replaced %d instances in %s", replaced, time.Now().Sub(timer).String())
+		return nil
+	})
+}
+
+// rollClusterParams returns the cluster stack parameters for a roll, the desired capacity
+// is kept at the current size as the capacity provider manages it
+func rollClusterParams(svc api.Services, stack *cloudformation.Stack, ami string, instanceTypes, publicSubnets []string) (map[string]string, error) {
+	params := map[string]string{}
+	previous := stackParameterMap(stack)
+
+	if group, ok := api.GetStackOutputByKey(stack, "AutoScalingGroup"); ok {
+		asg, err := api.DescribeAutoScalingGroup(svc.AutoScaling, group)
+		if err != nil {
+			return nil, err
+		}
+		params["DesiredCapacity"] = strconv.FormatInt(*asg.DesiredCapacity, 10)
+	}
+
+	// clusters created before the VPC's block was a parameter assumed the default network's block
+	if _, ok := previous["VpcCidr"]; !ok {
+		vpc, err := api.DescribeVpc(svc.EC2, previous["VpcId"])
+		if err != nil {
+			return nil, err
+		}
+		params["VpcCidr"] = *vpc.CidrBlock
+	}
+
+	// clusters created before subnet lists had a fixed pair of each
+	if _, ok := previous["PrivateSubnets"]; !ok && previous["VpcPrivateSubnet1Id"] != "" {
+		params["PrivateSubnets"] = previous["VpcPrivateSubnet1Id"] + "," + previous["VpcPrivateSubnet2Id"]
+
+		public, err := legacyPublicSubnets(svc, stack, publicSubnets)
+		if err != nil {
+			return nil, err
+		}
+		params["PublicSubnets"] = strings.Join(public, ",")
+	}
+
+	currentType, err := lookupInstanceType(previous["InstanceType"])
+	if err != nil {
+		return nil, err
+	}
+	arch := currentType.Arch
+
+	if len(instanceTypes) > 0 {
+		if len(instanceTypes) > maxInstanceTypes {
+			return nil, fmt.Errorf("Clusters support at most %d instance types", maxInstanceTypes)
+		}
+
+		instances, err := lookupInstanceTypes(instanceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		if instances[0].Arch != arch && ami == "" {
+			return nil, fmt.Errorf("Changing from %s to %s instances needs an --ami for the new architecture", arch, instances[0].Arch)
+		}
+		arch = instances[0].Arch
+
+		log.Printf("Changing instance types to %v", instanceTypes)
+		for k, v := range instanceTypeParams(instanceTypes) {
+			params[k] = v
+		}
+	}
+
+	if ami != "" {
+		amiParams, err := amiParams(ami, arch)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("Changing AMI to %s", ami)
+		params["AmiId"] = ""
+		for k, v := range amiParams {
+			params[k] = v
+		}
+	}
+
+	return params, nil
+}
+
+// legacyPublicSubnets finds the public subnets of a cluster created before subnet lists. The
+// oldest clusters had no load balancer and so no public subnets, those come from its network
+// stack or have to be given.
+func legacyPublicSubnets(svc api.Services, stack *cloudformation.Stack, publicSubnets []string) ([]string, error) {
+	previous := stackParameterMap(stack)
+	if previous["VpcPublicSubnet1Id"] != "" {
+		return []string{previous["VpcPublicSubnet1Id"], previous["VpcPublicSubnet2Id"]}, nil
+	} else if len(publicSubnets) > 0 {
+		return publicSubnets, nil
+	}
+
+	cluster, _ := api.GetStackOutputByKey(stack, "ECSCluster")
+	network, err := api.FindNetworkStack(svc.Cloudformation, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("Cluster %s has no public subnets and its network stack %s can't be used (%v). "+
+			"Use --public-subnet to give them", cluster, network.StackName, err)
+	}
+
+	log.Printf("Using public subnets %v from network stack %s", network.PublicSubnets, network.StackName)
+	return network.PublicSubnets, nil
+}
+
+// findOutdatedInstances returns the container instances that don't match the cluster's AMI
+// and instance types, draining instances left over from an interrupted roll come first.
+// The number of active instances that are up to date is also returned.
+func findOutdatedInstances(svc api.Services, cluster, imageID string, instanceTypes map[string]bool) ([]*ecs.ContainerInstance, int, error) {
+	instances, err := api.ListContainerInstances(svc.ECS, cluster)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	outdated := []*ecs.ContainerInstance{}
+	upToDate := 0
+
+	for _, instance := range instances {
+		if isInstanceUpToDate(instance, imageID, instanceTypes) {
+			if *instance.Status == api.ContainerInstanceStatusActive {
+				upToDate++
+			}
+			continue
+		}
+		outdated = append(outdated, instance)
+	}
+
+	sort.Slice(outdated, func(i, j int) bool {
+		iDraining := *outdated[i].Status == api.ContainerInstanceStatusDraining
+		jDraining := *outdated[j].Status == api.ContainerInstanceStatusDraining
+		if iDraining != jDraining {
+			return iDraining
+		}
+		return *outdated[i].Ec2InstanceId < *outdated[j].Ec2InstanceId
+	})
+
+	return outdated, upToDate, nil
+}
+
+func isInstanceUpToDate(instance *ecs.ContainerInstance, imageID string, instanceTypes map[string]bool) bool {
+	return *instance.Status != api.ContainerInstanceStatusDraining &&
+		api.ContainerInstanceAttribute(instance, "ecs.ami-id") == imageID &&
+		instanceTypes[api.ContainerInstanceAttribute(instance, "ecs.instance-type")]
+}
+
+// replaceInstances launches replacements for a batch of instances, waits for them to join the
+// cluster, drains the old instances and terminates them once services are stable again.
+// Draining instances already had replacements launched by an earlier run, so only active
+// instances get new ones, and capacity that was added but hasn't registered yet is reused.
+func replaceInstances(svc api.Services, cluster, group, imageID string, instanceTypes map[string]bool, batch []*ecs.ContainerInstance, registered, upToDate int) error {
+	launch := 0
+	for _, instance := range batch {
+		if *instance.Status != api.ContainerInstanceStatusDraining {
+			launch++
+		}
+	}
+
+	asg, err := api.DescribeAutoScalingGroup(svc.AutoScaling, group)
+	if err != nil {
+		return err
+	}
+
+	capacity := int64(registered + launch)
+	if capacity > *asg.MaxSize {
+		return fmt.Errorf("Replacing %d instances needs a capacity of %d, but %s has a maximum of %d. Use a smaller --batch-size",
+			len(batch), capacity, group, *asg.MaxSize)
+	}
+
+	if *asg.DesiredCapacity < capacity {
+		log.Printf("Launching %d replacement instances", capacity-*asg.DesiredCapacity)
+		if err = api.SetDesiredCapacity(svc.AutoScaling, group, capacity); err != nil {
+			return err
+		}
+	} else if launch > 0 {
+		log.Printf("Waiting for %d replacement instances that are already launching", launch)
+	}
+
+	if err = waitForUpToDateInstances(svc, cluster, imageID, instanceTypes, upToDate+launch); err != nil {
+		return err
+	}
+
+	for _, instance := range batch {
+		if _, err = drainInstance(svc, cluster, *instance.ContainerInstanceArn); err != nil {
+			return err
+		}
+	}
+
+	if err = waitForClusterServices(svc, cluster); err != nil {
+		return err
+	}
+
+	for _, instance := range batch {
+		log.Printf("Terminating instance %s", *instance.Ec2InstanceId)
+		if err = api.TerminateInstance(svc.AutoScaling, *instance.Ec2InstanceId, true); err != nil {
+			return err
+		}
+	}
+
+	for _, instance := range batch {
+		if err = api.PollUntilDeregistered(svc.ECS, cluster, *instance.ContainerInstanceArn, instanceDeregisterTimeout); err != nil {
+			return err
+		}
+		log.Printf("Instance %s has left the cluster", *instance.Ec2InstanceId)
+	}
+
+	return nil
+}
+
+func waitForUpToDateInstances(svc api.Services, cluster, imageID string, instanceTypes map[string]bool, count int) error {
+	deadline := time.Now().Add(instanceRegisterTimeout)
+	last := -1
+
+	for {
+		_, upToDate, err := findOutdatedInstances(svc, cluster, imageID, instanceTypes)
+		if err != nil {
+			return err
+		}
+
+		if upToDate != last {
+			log.Printf("%d of %d up to date instances have registered with the cluster", upToDate, count)
+			last = upToDate
+		}
+
+		if upToDate >= count {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Replacement instances didn't register with the cluster within %s", instanceRegisterTimeout)
+		}
+
+		time.Sleep(api.ECS_POLL_INTERVAL * 10)
+	}
+}
+
+func waitForClusterServices(svc api.Services, cluster string) error {
+	stacks, err := api.FindServiceStacks(svc.Cloudformation, cluster)
+	if err != nil {
+		return err
+	}
+
+	services := []string{}
+	for _, stack := range stacks {
+		if service, ok := api.GetStackOutputByKey(stack, "ECSService"); ok {
+			services = append(services, service)
+		}
+	}
+
+	log.Printf("Waiting for %d services to reach a steady state", len(services))
+	return api.PollUntilServicesStable(svc.ECS, cluster, services, func(pending []*ecs.Service) {
+		for _, service := range pending {
+			log.Printf("Service %s has %d of %d tasks running",
+				*service.ServiceName, *service.RunningCount, *service.DesiredCount)
+		}
+	})
+}
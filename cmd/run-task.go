@@ -20,6 +20,7 @@ func ConfigureRunTask(app *kingpin.Application, svc api.Services) {
 	var cluster, projectName, service string
 	var composeFiles []string
 	var commands []string
+	var placement placementFlags
 
 	cmd := app.Command("run-task", "Run a once-off task")
 	cmd.Alias("run")
@@ -42,67 +43,30 @@ func ConfigureRunTask(app *kingpin.Application, svc api.Services) {
 		Short('s').
 		StringVar(&service)
 
+	placement.register(cmd)
+
 	cmd.Arg("commands", "Commands to override the default task command with").
 		StringsVar(&commands)
 
 	cmd.Action(func(c *kingpin.ParseContext) error {
-		taskName := fmt.Sprintf("%s_%s_run", projectName, service)
-		log.Printf("Creating task %s on %s", taskName, cluster)
-
-		clusterStack, err := api.FindClusterStack(svc.Cloudformation, cluster)
-		if err != nil {
-			return err
-		}
-		if clusterStack == nil {
-			return fmt.Errorf("No cluster exists for %q. Use `create-cluster`",
-				cluster)
-		}
-
-		log.Printf("Generating task definition from %v", composeFiles)
-		t := compose.Transformer{
-			ComposeFiles: composeFiles,
-			ProjectName:  taskName,
-			Services:     []string{service},
-		}
-
-		taskDefinitionInput, err := t.Transform()
-		if err != nil {
-			return err
-		}
-
-		clusterOutput, err := api.StackOutputs(svc.Cloudformation, *clusterStack.StackName)
+		strategies, err := placement.placementStrategies()
 		if err != nil {
 			return err
 		}
 
-		logGroup, exists := clusterOutput["LogGroupName"]
-		if !exists {
-			return fmt.Errorf("Expected to find a LogGroupName in stack output")
-		}
-
-		log.Printf("Setting tasks to use log group %s", logGroup)
-		for _, def := range taskDefinitionInput.ContainerDefinitions {
-			if def.LogConfiguration == nil {
-				def.LogConfiguration = &ecs.LogConfiguration{
-					LogDriver: aws.String("awslogs"),
-					Options: map[string]*string{
-						"awslogs-group":         aws.String(logGroup),
-						"awslogs-region":        aws.String(os.Getenv("AWS_REGION")),
-						"awslogs-stream-prefix": aws.String(taskName),
-					},
-				}
-			}
-		}
+		taskName := fmt.Sprintf("%s_%s_run", projectName, service)
+		log.Printf("Creating task %s on %s", taskName, cluster)
 
-		log.Printf("Registering a task for %s", taskName)
-		resp, err := svc.ECS.RegisterTaskDefinition(taskDefinitionInput)
+		taskDef, logGroup, err := registerComposeServiceTask(svc, cluster, projectName, service, taskName, composeFiles)
 		if err != nil {
 			return err
 		}
 
 		taskDefinition := fmt.Sprintf("%s:%d",
-			*resp.TaskDefinition.Family, *resp.TaskDefinition.Revision)
+			*taskDef.Family, *taskDef.Revision)
 
+		// without a launch type, tasks use the cluster's default capacity provider strategy
+		// and wait in PROVISIONING while instances are added for them
 		runTaskInput := &ecs.RunTaskInput{
 			TaskDefinition: aws.String(taskDefinition),
 			Cluster:        aws.String(cluster),
@@ -129,7 +93,7 @@ func ConfigureRunTask(app *kingpin.Application, svc api.Services) {
 		}
 
 		log.Printf("Running task %s", taskDefinition)
-		runResp, err := svc.ECS.RunTask(runTaskInput)
+		runResp, err := api.RunTask(svc.ECS, runTaskInput, strategies, placement.placementConstraints())
 		if err != nil {
 			return err
 		}
@@ -194,3 +158,67 @@ func ConfigureRunTask(app *kingpin.Application, svc api.Services) {
 		return nil
 	})
 }
+
+// registerComposeServiceTask registers a task definition for a single compose service that
+// logs to the cluster's log group and runs with the role of the project's service
+func registerComposeServiceTask(svc api.Services, cluster, projectName, service, family string, composeFiles []string) (*ecs.TaskDefinition, string, error) {
+	clusterStack, err := api.FindClusterStack(svc.Cloudformation, cluster)
+	if err != nil {
+		return nil, "", err
+	}
+	if clusterStack == nil {
+		return nil, "", fmt.Errorf("No cluster exists for %q. Use `create-cluster`",
+			cluster)
+	}
+
+	log.Printf("Generating task definition from %v", composeFiles)
+	t := compose.Transformer{
+		ComposeFiles: composeFiles,
+		ProjectName:  family,
+		Services:     []string{service},
+	}
+
+	taskDefinitionInput, err := t.Transform()
+	if err != nil {
+		return nil, "", err
+	}
+
+	clusterOutput, err := api.StackOutputs(svc.Cloudformation, *clusterStack.StackName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logGroup, exists := clusterOutput["LogGroupName"]
+	if !exists {
+		return nil, "", fmt.Errorf("Expected to find a LogGroupName in stack output")
+	}
+
+	log.Printf("Setting tasks to use log group %s", logGroup)
+	for _, def := range taskDefinitionInput.ContainerDefinitions {
+		if def.LogConfiguration == nil {
+			def.LogConfiguration = &ecs.LogConfiguration{
+				LogDriver: aws.String("awslogs"),
+				Options: map[string]*string{
+					"awslogs-group":         aws.String(logGroup),
+					"awslogs-region":        aws.String(os.Getenv("AWS_REGION")),
+					"awslogs-stream-prefix": aws.String(family),
+				},
+			}
+		}
+	}
+
+	// one-off tasks get the same permissions as the project's service
+	if serviceStack, _ := api.FindServiceStack(svc.Cloudformation, cluster, projectName); serviceStack != nil {
+		if err = applyServiceTaskRole(svc, serviceStack, "", taskDefinitionInput); err != nil {
+			return nil, "", err
+		}
+	}
+
+	log.Printf("Registering a task for %s", family)
+	resp, err := svc.ECS.RegisterTaskDefinition(taskDefinitionInput)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.TaskDefinition, logGroup, nil
+}
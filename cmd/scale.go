@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/lox/ecsy/api"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// how long services have to reach a new number of running tasks
+const serviceScaleTimeout = 15 * time.Minute
+
+type scaleTarget struct {
+	projectName string
+	count       int64
+	stack       *cloudformation.Stack
+	service     string
+}
+
+func ConfigureScale(app *kingpin.Application, svc api.Services) {
+	var cluster string
+	var counts map[string]string
+
+	cmd := app.Command("scale", "Change the number of tasks running for services")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "A Compose project and the number of tasks to run, e.g app=5. Can be repeated").
+		Short('p').
+		Required().
+		StringMapVar(&counts)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		targets, err := findScaleTargets(svc, cluster, counts)
+		if err != nil {
+			return err
+		}
+
+		timer := time.Now()
+
+		for _, target := range targets {
+			log.Printf("Scaling %s to %d tasks", target.projectName, target.count)
+
+			// the stack's template and the service's deployed task definition are kept, so only the
+			// count changes however old the service is, and the stack and service can't disagree
+			if _, ok := api.GetStackParameterByKey(target.stack, "DesiredCount"); ok {
+				params, err := liveServiceParams(svc, cluster, target.stack)
+				if err != nil {
+					return err
+				}
+				params["DesiredCount"] = strconv.FormatInt(target.count, 10)
+
+				log.Printf("Updating desired count in service cloudformation stack %s", *target.stack.StackName)
+				err = api.UpdateStackAndWait(svc.Cloudformation, target.stack, "", api.UpdateStackContext{
+					Params: params,
+				}, func(event *cloudformation.StackEvent) {
+					log.Printf("%s\n", api.FormatStackEvent(event))
+				})
+				if err == nil {
+					continue
+				} else if err != api.ErrNoStackUpdates {
+					return err
+				}
+			}
+
+			// the stack already has the count or doesn't manage it, but the service may not agree
+			_, err = svc.ECS.UpdateService(&ecs.UpdateServiceInput{
+				Cluster:      aws.String(cluster),
+				Service:      aws.String(target.service),
+				DesiredCount: aws.Int64(target.count),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, target := range targets {
+			log.Printf("Waiting for %s to have %d running tasks", target.projectName, target.count)
+			err = api.PollUntilRunningCount(svc.ECS, cluster, target.service, target.count, serviceScaleTimeout, func(e *ecs.ServiceEvent) {
+				log.Printf("%s: %s", target.projectName, *e.Message)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Scaled in %s", time.Now().Sub(timer).String())
+		return nil
+	})
+}
+
+// findScaleTargets checks every service exists and the counts are valid before anything is scaled
+func findScaleTargets(svc api.Services, cluster string, counts map[string]string) ([]scaleTarget, error) {
+	targets := []scaleTarget{}
+
+	for projectName, val := range counts {
+		count, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("Invalid task count %q for %s, expected a positive number", val, projectName)
+		}
+
+		stack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
+		if err != nil {
+			return nil, err
+		} else if stack == nil {
+			return nil, fmt.Errorf("No service exists for %q in cluster %q", projectName, cluster)
+		}
+
+		params := stackParameterMap(stack)
+		if params["SchedulingStrategy"] == daemonSchedulingStrategy {
+			return nil, fmt.Errorf("Service %s is a daemon and runs one task on every instance, it can't be scaled", projectName)
+		}
+
+		// auto scaling would immediately move the count back within its bounds
+		if _, ok := api.GetStackOutputByKey(stack, "ScalableTarget"); ok {
+			min, _ := strconv.ParseInt(params["MinCount"], 10, 64)
+			max, _ := strconv.ParseInt(params["MaxCount"], 10, 64)
+			if count < min || count > max {
+				return nil, fmt.Errorf("Service %s auto scales between %d and %d tasks. Use `update-service` to change the bounds",
+					projectName, min, max)
+			}
+		}
+
+		service, _ := api.GetStackOutputByKey(stack, "ECSService")
+		targets = append(targets, scaleTarget{
+			projectName: projectName,
+			count:       count,
+			stack:       stack,
+			service:     service,
+		})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].projectName < targets[j].projectName
+	})
+
+	return targets, nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// scalingFlags are the task count and auto scaling flags shared by create-service
+// and update-service, values are kept as strings so unset flags can be detected
+type scalingFlags struct {
+	desiredCount, minCount, maxCount string
+	cpuTarget, memoryTarget          string
+	requestCountTarget               string
+}
+
+func (f *scalingFlags) register(cmd *kingpin.CmdClause) {
+	cmd.Flag("desired-count", "The number of tasks to run").
+		StringVar(&f.desiredCount)
+
+	cmd.Flag("min-count", "The minimum number of tasks auto scaling can scale in to, defaults to the desired count").
+		StringVar(&f.minCount)
+
+	cmd.Flag("max-count", "The maximum number of tasks auto scaling can scale out to, defaults to the desired count").
+		StringVar(&f.maxCount)
+
+	cmd.Flag("scale-cpu", "Scale to keep the average CPU utilization at this percentage, 0 disables").
+		StringVar(&f.cpuTarget)
+
+	cmd.Flag("scale-memory", "Scale to keep the average memory utilization at this percentage, 0 disables").
+		StringVar(&f.memoryTarget)
+
+	cmd.Flag("scale-requests", "Scale to keep the load balancer requests per task at this count, 0 disables").
+		StringVar(&f.requestCountTarget)
+}
+
+// isSet returns whether any of the scaling flags were provided
+func (f *scalingFlags) isSet() bool {
+	return f.desiredCount != "" || f.minCount != "" || f.maxCount != "" ||
+		f.cpuTarget != "" || f.memoryTarget != "" || f.requestCountTarget != ""
+}
+
+// params resolves the flags against the previous stack parameters into service
+// stack parameters, flags that weren't provided keep their previous values
+func (f *scalingFlags) params(previous map[string]string, hasLoadBalancer bool) (map[string]string, error) {
+	desired, err := resolveIntFlag(previous, "desired-count", f.desiredCount, "DesiredCount", "1")
+	if err != nil {
+		return nil, err
+	}
+
+	min, err := resolveIntFlag(previous, "min-count", f.minCount, "MinCount", strconv.FormatInt(desired, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	max, err := resolveIntFlag(previous, "max-count", f.maxCount, "MaxCount", strconv.FormatInt(desired, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	cpu, err := resolveIntFlag(previous, "scale-cpu", f.cpuTarget, "ScalingCPUTarget", "0")
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := resolveIntFlag(previous, "scale-memory", f.memoryTarget, "ScalingMemoryTarget", "0")
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := resolveIntFlag(previous, "scale-requests", f.requestCountTarget, "ScalingRequestCountTarget", "0")
+	if err != nil {
+		return nil, err
+	}
+
+	if cpu > 100 || memory > 100 {
+		return nil, fmt.Errorf("CPU and memory scaling targets must be a percentage between 1 and 100")
+	}
+
+	if requests > 0 && !hasLoadBalancer {
+		return nil, fmt.Errorf("Scaling on request count requires a service with a load balancer")
+	}
+
+	if min > max {
+		return nil, fmt.Errorf("The minimum count %d is greater than the maximum count %d", min, max)
+	}
+
+	// the desired count is only bounded when there is a policy to do the bounding
+	if (cpu > 0 || memory > 0 || requests > 0) && (desired < min || desired > max) {
+		return nil, fmt.Errorf("The desired count %d must be between the minimum %d and maximum %d counts", desired, min, max)
+	}
+
+	return map[string]string{
+		"DesiredCount":              strconv.FormatInt(desired, 10),
+		"MinCount":                  strconv.FormatInt(min, 10),
+		"MaxCount":                  strconv.FormatInt(max, 10),
+		"ScalingCPUTarget":          strconv.FormatInt(cpu, 10),
+		"ScalingMemoryTarget":       strconv.FormatInt(memory, 10),
+		"ScalingRequestCountTarget": strconv.FormatInt(requests, 10),
+	}, nil
+}
+
+// resolveIntFlag parses a flag, falling back to the previous stack parameter and then the default
+func resolveIntFlag(previous map[string]string, name, flag, param, fallback string) (int64, error) {
+	val := flag
+	if val == "" {
+		val = previous[param]
+	}
+	if val == "" {
+		val = fallback
+	}
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("Invalid value %q for --%s, expected a positive number", val, name)
+	}
+	return i, nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScalingFlagsParams(t *testing.T) {
+	for _, tc := range []struct {
+		flags    scalingFlags
+		previous map[string]string
+		expected map[string]string
+	}{
+		{
+			scalingFlags{},
+			map[string]string{},
+			map[string]string{"DesiredCount": "1", "MinCount": "1", "MaxCount": "1",
+				"ScalingCPUTarget": "0", "ScalingMemoryTarget": "0", "ScalingRequestCountTarget": "0"},
+		},
+		{
+			scalingFlags{desiredCount: "3"},
+			map[string]string{},
+			map[string]string{"DesiredCount": "3", "MinCount": "3", "MaxCount": "3",
+				"ScalingCPUTarget": "0", "ScalingMemoryTarget": "0", "ScalingRequestCountTarget": "0"},
+		},
+		{
+			scalingFlags{maxCount: "10", cpuTarget: "60"},
+			map[string]string{"DesiredCount": "2", "MinCount": "2", "MaxCount": "2", "ScalingRequestCountTarget": "100"},
+			map[string]string{"DesiredCount": "2", "MinCount": "2", "MaxCount": "10",
+				"ScalingCPUTarget": "60", "ScalingMemoryTarget": "0", "ScalingRequestCountTarget": "100"},
+		},
+		{
+			scalingFlags{desiredCount: "12"},
+			map[string]string{"MinCount": "2", "MaxCount": "10"},
+			map[string]string{"DesiredCount": "12", "MinCount": "2", "MaxCount": "10",
+				"ScalingCPUTarget": "0", "ScalingMemoryTarget": "0", "ScalingRequestCountTarget": "0"},
+		},
+	} {
+		actual, err := tc.flags.params(tc.previous, true)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc.flags, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.flags, tc.expected, actual)
+		}
+	}
+}
+
+func TestScalingFlagsParamsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		flags           scalingFlags
+		previous        map[string]string
+		hasLoadBalancer bool
+	}{
+		{scalingFlags{desiredCount: "-1"}, map[string]string{}, true},
+		{scalingFlags{desiredCount: "two"}, map[string]string{}, true},
+		{scalingFlags{minCount: "5", maxCount: "2"}, map[string]string{}, true},
+		{scalingFlags{cpuTarget: "150"}, map[string]string{}, true},
+		{scalingFlags{requestCountTarget: "100"}, map[string]string{}, false},
+		{scalingFlags{desiredCount: "12", cpuTarget: "50"}, map[string]string{"MinCount": "2", "MaxCount": "10"}, true},
+	} {
+		if _, err := tc.flags.params(tc.previous, tc.hasLoadBalancer); err == nil {
+			t.Errorf("%+v: expected an error", tc.flags)
+		}
+	}
+}
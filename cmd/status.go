@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/lox/ecsy/api"
+	"github.com/mattn/go-isatty"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const statusEventCount = 5
+
+func ConfigureStatus(app *kingpin.Application, svc api.Services) {
+	var cluster, projectName string
+	var watch bool
+	var interval time.Duration
+
+	cmd := app.Command("status", "Show the health of a cluster or a service")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "Only show the service for this Compose project").
+		Short('p').
+		StringVar(&projectName)
+
+	cmd.Flag("watch", "Refresh the report until interrupted").
+		Short('w').
+		BoolVar(&watch)
+
+	cmd.Flag("interval", "How often to refresh the report when watching").
+		Default("5s").
+		DurationVar(&interval)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		terminal := isTerminal(os.Stdout)
+
+		for {
+			buf := &bytes.Buffer{}
+			err := writeStatus(buf, svc, cluster, projectName)
+
+			if !watch {
+				if err != nil {
+					return err
+				}
+				_, err = buf.WriteTo(os.Stdout)
+				return err
+			}
+
+			// errors while watching are usually transient, so the report is tried again
+			if err != nil {
+				log.Printf("Failed to refresh the status: %v", err)
+				time.Sleep(interval)
+				continue
+			}
+
+			// move the cursor home and clear the screen before redrawing
+			if terminal {
+				fmt.Print("\033[H\033[2J")
+			}
+			fmt.Printf("Every %s: ecsy status (%s)\n\n", interval, time.Now().Format(time.Stamp))
+			if _, err := buf.WriteTo(os.Stdout); err != nil {
+				return err
+			}
+
+			time.Sleep(interval)
+		}
+	})
+}
+
+func writeStatus(w io.Writer, svc api.Services, cluster, projectName string) error {
+	clusterStack, err := api.FindClusterStack(svc.Cloudformation, cluster)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Cluster %s (%s)\n\n", cluster, formatStackStatus(clusterStack))
+
+	if err = writeContainerInstances(w, svc, cluster); err != nil {
+		return err
+	}
+
+	var serviceStacks []*cloudformation.Stack
+	if projectName != "" {
+		stack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
+		if err != nil {
+			return err
+		}
+		serviceStacks = []*cloudformation.Stack{stack}
+	} else {
+		serviceStacks, err = api.FindServiceStacks(svc.Cloudformation, cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	serviceNames := []string{}
+	for _, stack := range serviceStacks {
+		serviceNames = append(serviceNames, api.StackOutputMap(stack)["ECSService"])
+	}
+
+	services, err := api.DescribeServices(svc.ECS, cluster, serviceNames)
+	if err != nil {
+		return err
+	}
+
+	for _, stack := range serviceStacks {
+		outputs := api.StackOutputMap(stack)
+		fmt.Fprintf(w, "\nService %s (%s)\n\n", outputs["TaskFamily"], formatStackStatus(stack))
+
+		service, ok := services[outputs["ECSService"]]
+		if !ok {
+			fmt.Fprintf(w, "ECS service %s not found\n", outputs["ECSService"])
+			continue
+		}
+
+		if err = writeDeployments(w, service); err != nil {
+			return err
+		}
+
+		// only show the detail for a single service, the cluster report would be too noisy
+		if projectName == "" {
+			continue
+		}
+
+		if err = writeLoadBalancerHealth(w, svc, service); err != nil {
+			return err
+		}
+
+		writeServiceEvents(w, service)
+	}
+
+	return nil
+}
+
+func formatStackStatus(stack *cloudformation.Stack) string {
+	return fmt.Sprintf("%s: %s", *stack.StackName, *stack.StackStatus)
+}
+
+func writeContainerInstances(w io.Writer, svc api.Services, cluster string) error {
+	instances, err := api.ListContainerInstances(svc.ECS, cluster)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{}
+	for _, instance := range instances {
+		rows = append(rows, []string{
+			*instance.Ec2InstanceId,
+			*instance.Status,
+			strconv.FormatBool(*instance.AgentConnected),
+			strconv.FormatInt(*instance.RunningTasksCount, 10),
+			strconv.FormatInt(*instance.PendingTasksCount, 10),
+			formatResource(instance, "CPU"),
+			formatResource(instance, "MEMORY"),
+		})
+	}
+
+	return writeTable(w, []string{"INSTANCE", "STATUS", "AGENT CONNECTED", "RUNNING", "PENDING", "CPU FREE", "MEMORY FREE"}, rows)
+}
+
+// formatResource shows the remaining and registered amount of a resource, e.g 512/2048
+func formatResource(instance *ecs.ContainerInstance, name string) string {
+	var remaining, registered int64
+	for _, r := range instance.RemainingResources {
+		if *r.Name == name && r.IntegerValue != nil {
+			remaining = *r.IntegerValue
+		}
+	}
+	for _, r := range instance.RegisteredResources {
+		if *r.Name == name && r.IntegerValue != nil {
+			registered = *r.IntegerValue
+		}
+	}
+	return fmt.Sprintf("%d/%d", remaining, registered)
+}
+
+func writeDeployments(w io.Writer, service *ecs.Service) error {
+	rows := [][]string{}
+	for _, d := range service.Deployments {
+		rows = append(rows, []string{
+			*d.Status,
+			fmt.Sprintf("%s:%s", *service.ServiceName, api.TaskDefinitionRevision(*d.TaskDefinition)),
+			strconv.FormatInt(*d.DesiredCount, 10),
+			strconv.FormatInt(*d.PendingCount, 10),
+			strconv.FormatInt(*d.RunningCount, 10),
+			d.UpdatedAt.Local().Format(time.Stamp),
+		})
+	}
+
+	return writeTable(w, []string{"DEPLOYMENT", "TASK DEFINITION", "DESIRED", "PENDING", "RUNNING", "UPDATED"}, rows)
+}
+
+func writeLoadBalancerHealth(w io.Writer, svc api.Services, service *ecs.Service) error {
+	for _, lb := range service.LoadBalancers {
+		if lb.TargetGroupArn != nil {
+			if err := writeTargetGroupHealth(w, svc, *lb.TargetGroupArn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// services created before the move to ALBs use classic load balancers
+		resp, err := svc.ELB.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+			LoadBalancerName: lb.LoadBalancerName,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "\nLoad Balancer %s\n\n", *lb.LoadBalancerName)
+
+		rows := [][]string{}
+		for _, state := range resp.InstanceStates {
+			rows = append(rows, []string{
+				*state.InstanceId,
+				*state.State,
+				valueOrDash(aws.StringValue(state.Description)),
+			})
+		}
+
+		if err = writeTable(w, []string{"INSTANCE", "STATE", "DESCRIPTION"}, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeTargetGroupHealth(w io.Writer, svc api.Services, targetGroupArn string) error {
+	resp, err := svc.ELBV2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTarget Group %s\n\n", targetGroupArn)
+
+	rows := [][]string{}
+	for _, target := range resp.TargetHealthDescriptions {
+		rows = append(rows, []string{
+			*target.Target.Id,
+			strconv.FormatInt(aws.Int64Value(target.Target.Port), 10),
+			*target.TargetHealth.State,
+			valueOrDash(aws.StringValue(target.TargetHealth.Description)),
+		})
+	}
+
+	return writeTable(w, []string{"INSTANCE", "PORT", "STATE", "DESCRIPTION"}, rows)
+}
+
+func writeServiceEvents(w io.Writer, service *ecs.Service) {
+	fmt.Fprintf(w, "\nRecent Events\n\n")
+
+	for idx, event := range service.Events {
+		if idx >= statusEventCount {
+			break
+		}
+		fmt.Fprintf(w, "%-20s %s\n", event.CreatedAt.Local().Format(time.Stamp), *event.Message)
+	}
+}
+
+// isTerminal returns whether a file is a terminal rather than a pipe or a regular file
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd())
+}
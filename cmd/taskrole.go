@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/lox/ecsy/api"
+)
+
+// looked for next to the first compose file when --task-policy isn't given
+const taskPolicyFileName = "task-policy.json"
+
+const (
+	// IAM role names can't be any longer
+	maxRoleNameLength = 64
+
+	// the policy is passed to the service stack as a parameter, which can't be any longer
+	maxTaskPolicyLength = 4096
+)
+
+// taskRoleName returns the name of the role created for a service, long names are shortened
+// and made unique with a hash of the full name
+func taskRoleName(cluster, projectName string) string {
+	name := fmt.Sprintf("ecsy-%s-%s-task", cluster, projectName)
+	if len(name) <= maxRoleNameLength {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha1.Sum([]byte(name)))[:8]
+	return name[:maxRoleNameLength-len(hash)-1] + "-" + hash
+}
+
+// taskRoleArn returns the ARN a role created by a stack in the same account as the cluster
+// stack will have, so tasks can be registered with it before the role exists
+func taskRoleArn(clusterStack *cloudformation.Stack, roleName string) (string, error) {
+	// arn:partition:cloudformation:region:account:stack/name/id
+	parts := strings.Split(*clusterStack.StackId, ":")
+	if len(parts) < 6 {
+		return "", fmt.Errorf("Unexpected stack ARN %s", *clusterStack.StackId)
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/ecsy/%s", parts[1], parts[4], roleName), nil
+}
+
+// readTaskPolicy returns the compacted contents of a policy file
+func readTaskPolicy(policyFile string) (string, error) {
+	document, err := ioutil.ReadFile(policyFile)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = json.Compact(buf, document); err != nil {
+		return "", fmt.Errorf("Task policy %s isn't valid json: %v", policyFile, err)
+	}
+
+	if buf.Len() > maxTaskPolicyLength {
+		return "", fmt.Errorf("Task policy %s is longer than %d characters, use --task-role with a role you manage instead",
+			policyFile, maxTaskPolicyLength)
+	}
+
+	return buf.String(), nil
+}
+
+// findTaskPolicyFile returns the policy file for a project, or an empty string if there isn't one
+func findTaskPolicyFile(policyFile string, composeFiles []string) string {
+	if policyFile != "" || len(composeFiles) == 0 {
+		return policyFile
+	}
+
+	path := filepath.Join(filepath.Dir(composeFiles[0]), taskPolicyFileName)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// applyServiceTaskRole sets the task role of a service on a task definition, keeping
+// the policy of roles created by ecsy in sync with the policy file
+func applyServiceTaskRole(svc api.Services, stack *cloudformation.Stack, policyFile string, input *ecs.RegisterTaskDefinitionInput) error {
+	outputs := api.StackOutputMap(stack)
+	params := stackParameterMap(stack)
+
+	if policyFile != "" {
+		document, err := readTaskPolicy(policyFile)
+		if err != nil {
+			return err
+		}
+
+		if params["TaskRoleName"] != "" && params["TaskPolicy"] != document {
+			// the new revision isn't registered yet, the update has to keep the deployed one
+			live, err := liveServiceParams(svc, outputs["ECSCluster"], stack)
+			if err != nil {
+				return err
+			}
+			live["TaskPolicy"] = document
+
+			log.Printf("Applying policy %s to task role %s", policyFile, params["TaskRoleName"])
+			err = api.UpdateStackAndWait(svc.Cloudformation, stack, "", api.UpdateStackContext{
+				Params: live,
+			}, func(event *cloudformation.StackEvent) {
+				log.Printf("%s\n", api.FormatStackEvent(event))
+			})
+			if err != nil && err != api.ErrNoStackUpdates {
+				return err
+			}
+		}
+	}
+
+	if roleArn, ok := outputs["TaskRole"]; ok {
+		log.Printf("Setting tasks to use role %s", roleArn)
+		input.TaskRoleArn = &roleArn
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/lox/ecsy/api"
+	"github.com/lox/ecsy/templates"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func ConfigureUpdateService(app *kingpin.Application, svc api.Services) {
+	var cluster, projectName string
+	var scaling scalingFlags
+	var healthChecks healthCheckFlags
+
+	cmd := app.Command("update-service", "Update the settings of an existing ECS service")
+	cmd.Flag("cluster", "The name of the ECS cluster").
+		Required().
+		StringVar(&cluster)
+
+	cmd.Flag("project-name", "The name of the Compose project").
+		Short('p').
+		Default(currentDirName()).
+		StringVar(&projectName)
+
+	scaling.register(cmd)
+	healthChecks.register(cmd)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		stack, err := api.FindServiceStack(svc.Cloudformation, cluster, projectName)
+		if err != nil {
+			return err
+		} else if stack == nil {
+			return fmt.Errorf("No service exists for %q in cluster %q. Use `create-service`",
+				projectName, cluster)
+		}
+
+		previous := stackParameterMap(stack)
+
+		ctx := api.UpdateStackContext{
+			Params: map[string]string{},
+		}
+
+		live, err := liveServiceParams(svc, cluster, stack)
+		if err != nil {
+			return err
+		}
+		for k, v := range live {
+			ctx.Params[k] = v
+			previous[k] = v
+		}
+
+		if previous["SchedulingStrategy"] == daemonSchedulingStrategy {
+			if scaling.isSet() {
+				return fmt.Errorf("Service %s is a daemon and runs one task on every instance, it can't be scaled", projectName)
+			}
+		} else {
+			scalingParams, err := scaling.params(previous, previous["ContainerName"] != "")
+			if err != nil {
+				return err
+			}
+			for k, v := range scalingParams {
+				ctx.Params[k] = v
+			}
+		}
+
+		healthCheckParams, err := healthChecks.params(previous)
+		if err != nil {
+			return err
+		}
+		for k, v := range healthCheckParams {
+			ctx.Params[k] = v
+		}
+
+		timer := time.Now()
+		log.Printf("Updating service cloudformation stack %s", *stack.StackName)
+
+		err = api.UpdateStackAndWait(svc.Cloudformation, stack, templates.EcsService(), ctx, func(event *cloudformation.StackEvent) {
+			log.Printf("%s\n", api.FormatStackEvent(event))
+		})
+		if err == api.ErrNoStackUpdates {
+			log.Printf("Service %s is already up to date", projectName)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		log.Printf("Service updated in %s", time.Now().Sub(timer).String())
+		return nil
+	})
+}
+
+func stackParameterMap(stack *cloudformation.Stack) map[string]string {
+	params := map[string]string{}
+	for _, param := range stack.Parameters {
+		if param.ParameterValue != nil {
+			params[*param.ParameterKey] = *param.ParameterValue
+		}
+	}
+	return params
+}
+
+// liveServiceParams returns the parameters of a service stack that deploy, scale and auto
+// scaling change on the service directly. Stack updates have to pass them or they would
+// revert those changes.
+func liveServiceParams(svc api.Services, cluster string, stack *cloudformation.Stack) (map[string]string, error) {
+	params := map[string]string{}
+
+	serviceName, _ := api.GetStackOutputByKey(stack, "ECSService")
+	services, err := api.DescribeServices(svc.ECS, cluster, []string{serviceName})
+	if err != nil {
+		return nil, err
+	}
+	if service, ok := services[serviceName]; ok {
+		params["TaskDefinition"] = *service.TaskDefinition
+		if _, ok := api.GetStackParameterByKey(stack, "DesiredCount"); ok {
+			params["DesiredCount"] = strconv.FormatInt(*service.DesiredCount, 10)
+		}
+	}
+
+	return params, nil
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/docker/libcompose/project"
 )
 
+// DaemonLabel marks a compose service as a daemon that runs on every instance
+const DaemonLabel = "ecsy.daemon"
+
 type Transformer struct {
 	ComposeFiles      []string
 	ProjectName       string
@@ -296,8 +299,11 @@ func (t *Transformer) Transform() (*ecs.RegisterTaskDefinitionInput, error) {
 			}
 		}
 
-		if config.Labels != nil {
-			return nil, fmt.Errorf("Labels directive not supported")
+		if len(config.Labels) > 0 {
+			def.DockerLabels = map[string]*string{}
+			for k, v := range config.Labels {
+				def.DockerLabels[k] = aws.String(v)
+			}
 		}
 
 		if len(config.Ulimits.Elements) > 0 {
@@ -310,6 +316,25 @@ func (t *Transformer) Transform() (*ecs.RegisterTaskDefinitionInput, error) {
 	return &task, nil
 }
 
+// IsDaemon returns whether the containers in a task are daemons. Daemons run as their own
+// project, so a task can't have both daemons and other containers.
+func IsDaemon(task *ecs.RegisterTaskDefinitionInput) (bool, error) {
+	daemons := 0
+	for _, def := range task.ContainerDefinitions {
+		if label, ok := def.DockerLabels[DaemonLabel]; ok {
+			if isDaemon, _ := strconv.ParseBool(aws.StringValue(label)); isDaemon {
+				daemons++
+			}
+		}
+	}
+
+	if daemons > 0 && daemons != len(task.ContainerDefinitions) {
+		return false, fmt.Errorf("Services labelled %s can't be in the same project as other services", DaemonLabel)
+	}
+
+	return daemons > 0, nil
+}
+
 func isServiceIncluded(name string, included []string) bool {
 	if len(included) == 0 {
 		return true
@@ -8,10 +8,14 @@ func TestTransformHelloWorld(t *testing.T) {
 		ProjectName:  "helloworld",
 	}
 
-	_, err := trf.Transform()
+	task, err := trf.Transform()
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	if isDaemon, err := IsDaemon(task); err != nil || isDaemon {
+		t.Fatalf("Expected helloworld not to be a daemon, got %v (%v)", isDaemon, err)
+	}
 }
 
 func TestTransformComplex(t *testing.T) {
@@ -29,3 +33,27 @@ func TestTransformComplex(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTransformDaemons(t *testing.T) {
+	trf := Transformer{
+		ComposeFiles: []string{"../examples/daemons/docker-compose.yml"},
+		ProjectName:  "agents",
+		EnvironmentLookup: envMap{
+			"DATADOG_API_KEY": []string{"abc123"},
+			"LOGSPOUT_TARGET": []string{"syslog://logs.example.org:514"},
+		},
+	}
+
+	task, err := trf.Transform()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isDaemon, err := IsDaemon(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isDaemon {
+		t.Fatal("Expected agents to be a daemon")
+	}
+}
@@ -11,7 +11,7 @@ require (
 	github.com/go-ini/ini v1.63.2 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	github.com/mattn/go-colorable v0.0.7 // indirect
-	github.com/mattn/go-isatty v0.0.0-20161123143637-30a891c33c7c // indirect
+	github.com/mattn/go-isatty v0.0.0-20161123143637-30a891c33c7c
 	github.com/pkg/errors v0.9.1 // indirect
 	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550 // indirect
 	golang.org/x/net v0.0.0-20210805182204-aaa1db679c0d
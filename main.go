@@ -27,8 +27,19 @@ func run(args []string, exit func(code int)) {
 
 	cmd.ConfigureCreateCluster(app, api.DefaultServices)
 	cmd.ConfigureDeleteCluster(app, api.DefaultServices)
+	cmd.ConfigureListClusters(app, api.DefaultServices)
+	cmd.ConfigureRollCluster(app, api.DefaultServices)
+	cmd.ConfigureDrainInstance(app, api.DefaultServices)
 	cmd.ConfigureCreateService(app, api.DefaultServices)
+	cmd.ConfigureUpdateService(app, api.DefaultServices)
+	cmd.ConfigureScale(app, api.DefaultServices)
+	cmd.ConfigureDeleteService(app, api.DefaultServices)
+	cmd.ConfigureListServices(app, api.DefaultServices)
+	cmd.ConfigureCreateSchedule(app, api.DefaultServices)
+	cmd.ConfigureListSchedules(app, api.DefaultServices)
+	cmd.ConfigureDeleteSchedule(app, api.DefaultServices)
 	cmd.ConfigurePollStack(app, api.DefaultServices)
+	cmd.ConfigureStatus(app, api.DefaultServices)
 	cmd.ConfigureDeploy(app, api.DefaultServices)
 	cmd.ConfigureDumpTaskDefinition(app, api.DefaultServices)
 	cmd.ConfigureLogs(app, api.DefaultServices)
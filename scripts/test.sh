@@ -5,7 +5,7 @@ make setup build
 
 # Make sure nothing is around before we start.
 echo "~~~ :aws: Delete any existing clusters"
-./ecsy delete-cluster --cluster=ecsy-test
+./ecsy delete-cluster --force --cluster=ecsy-test
 
 echo "--- :aws: Create cluster"
 ./ecsy create-cluster --cluster=ecsy-test --count=2 --type t2.nano --keyname "${KEYNAME:-default}"
@@ -19,5 +19,8 @@ echo "--- :aws: Running once-off task"
 echo "--- :aws: Deploy update"
 ./ecsy deploy --cluster=ecsy-test -p app -f ./examples/helloworld/docker-compose.yml
 
+echo "--- :aws: Delete service"
+./ecsy delete-service --cluster=ecsy-test -p app --deregister-tasks
+
 echo "--- :aws: Delete cluster"
-./ecsy delete-cluster --cluster=ecsy-test
\ No newline at end of file
+./ecsy delete-cluster --force --cluster=ecsy-test
\ No newline at end of file
@@ -0,0 +1,18 @@
+#!/bin/bash
+set -euo pipefail
+
+# Regenerates the instance type catalogue that create-cluster validates --type against.
+# Instance types vary by region, so run this against a region with the widest selection.
+
+aws ec2 describe-instance-types --region "${AWS_REGION:-us-east-1}" --query 'InstanceTypes' --output json \
+  | jq -S 'map({
+      key: .InstanceType,
+      value: {
+        vcpus: .VCpuInfo.DefaultVCpus,
+        memoryMiB: .MemoryInfo.SizeInMiB,
+        arch: (if (.ProcessorInfo.SupportedArchitectures | index("arm64")) then "arm64" else "x86_64" end)
+      }
+    }) | from_entries' \
+  > templates/src/instance-types.json
+
+make templates
@@ -209,116 +209,313 @@ func FSMustString(useLocal bool, name string) string {
 
 var _escData = map[string]*_escFile{
 
+	"/templates/src/ecs-schedule.yml": {
+		name:    "ecs-schedule.yml",
+		local:   "templates/src/ecs-schedule.yml",
+		size:    2511,
+		modtime: 1760000000,
+		compressed: `
+H4sIAAAAAAAC/6xVUW/bNhB+16/46gXoFkypuz2VDxvUxB0CtJlhG+3DsAeWOtlEJNIgT82yIP99ICVZ
+kW0lM1A9ibz77u67+0imaZpkX5YrqralZPpgXSX5MzmvrRF4/cv07TSdvkun714nV+SV01uOlt8SAJhd
+LrFUG8rrkgQyg9k3Mvze6XxNcHVJ4I1kuNp4SKykv8UVFdroEATWQMK38CSZSycrYnJedMEvy9ozuWYN
+AKv7LQks2Wmz3m0OClttKCChGijYhvTgDYFDfmuSBEAs5oOsdHl/avQiomBkRbBFH1kb1J766D3TUzPo
+nAzrQpPrEgRGw5gtsSZdN4PZP1tH3p+Ycm9u3URAu2g/gy7WUM6aH6f4Fec4x+84/6nnemlrw/s5b+rq
+K7lxmibaI0Xpb33LCCTVBqwreoIsZF2ywNvd1idtPsuyprCX/FnztuZWNkuW6jYWsHNuPSekfFpYV5ET
+Ivx3TCfJqOBa6KsFFU/s4xJ66t/bX57SU9yhX4NfhFN2FLGIB2hB3tZOUduIoXszkuzLUog4bC9ERAEA
+AMyd3ZJj3aGPTu3Vsv6KySIc57OHnt4jrMHZQ9+fx8kgxhHio0wHOJZMArOb7P3H2dXAtJJuTbxXKwCk
+uM4P+4+DL3M7PtIZIe98kIQ4e4gtWtBaW/PYLTOlgsCv80fR3itvnqELAMDCltQk+YM4Y47zCJsXmTNH
+/GfK79+Ah9/wDmjCd1T7/WfAzUHtQXHdyyvUd1Qz19knIYL1RcFk3tdVDDS3pVb3V1bVFRkeeu3Ge9wE
+AClmRUGKBbKytHdHfUIZ2ii9laUYcQCAJblvWpHAX6Ao/gtZyX+tkXf+QtkKf49gM9Xo3rMXPa+B81zy
+RuDNcC8QP2gMAKSN7f5GViSwqE2YQXKE1Qut+18NPKWNQ77hJIwX13/djXOKCgHgh/bGv9O8gYwLOFsS
+DFEOzdhK7ykH2/D6Vd+ZnZaVmEvvD2Y5Tm9yPnnW89KafO+pH/ual/ijvqWXfQHsqqV8ZXc6Dq9XbOFQ
+yMl/AwCUsVwezwkAAA==
+`,
+	},
+
 	"/templates/src/ecs-service.yml": {
 		name:    "ecs-service.yml",
 		local:   "templates/src/ecs-service.yml",
-		size:    6040,
-		modtime: 1634116747,
+		size:    40774,
+		modtime: 1760000000,
 		compressed: `
-H4sIAAAAAAAC/+xYT2/buBO961NMjQAFijpx3P5+2PKwgCO7WwPerBE56aHogaZGFlGJ1JJUgzTod19Q
-km1Rfxy72V3socrFEGfeG3LejIYZDofe5GOwwjRLqMH3UqXU3KHSXAoCL8ejy9Fw9G44evfSm6Jmimem
-WPnVAwCY+QEEqL5yhgQm259ARQgUVlR/gSlGXHDr43lLqmiKBpUmhfddxuZh+dM+q4fMonwMCJn5Y0Lu
-lj4h83C37vCvYgQeojA84qhARnC39MFIULkALrwtwTJfJ5wF+VqguTzEVpocJoy40gayAhJ04QBcgImx
-YNcZMhtOCPfcxOX2OgMZPzcQjUyK8EcimfmBn+TaoGpGEBjFxaaf0+aala72nKkxlMUFo67SbuSOI0CW
-K24eflMyzw7s1THr3fLEbrgwhI21BBNTA4wKoIyh1kVsXGhDBUNdBmHV956mPHk4daNR4QWCpmh1ZXdo
-rJS5gFzjv4W+L5xTGdyyMFXqXEybwR2dL4WhXKC6pimeysa2znVRyLouGiRLqUyT5DpP16j6STKpDMhS
-3w6hzFDU3CKaJ4bAL6NKiIur57IlkoawpolV1hGMH5AmJvZjZF9uVXLqWd7eLCxFzA3cxygglFxsIC4w
-mcXUECmZljldXLXDuCijCIKFj8pqgFGD7WZzKI6JaAiICosHbA9YiQciqfpDGQw8z5ciLORWNfxbjR+M
-yRZcGxT1DvRi9mdOEw2f4MUNRq34X8NgAJ+9OobuALmWxiIcg2XR/shNlpsqtMBQ9qU4nR3eHU1yJDBA
-poeRVCkqQuzvStaD/oZaeRb8+/Wd/ULS8KqSVNtpHsGn3cvtM2hue/C6ZfMiyNcwiK0Vubg4e/ywWi0d
-rvPpdWBL/Ds5e6xK4/tBnB3MkygOyOfaZ6CcDfpOJqh3iK6eWrffr3veDWqZK4ZV9maLqx/54uyslkpm
-ViRbvN1TmNXqAwgE7qfIFsFscWX7d6SkMLZkZn7gwhQfYKj2UX6NnXUnqrnYKNTNSABgCPNsqaSRTCYE
-DMtaFgDvlUyLnledcZmgDsOVPMrM56GaZwRG58XfxeiZUb19++ZAMN2r7RjKXtsQZnfSE6oNZ3s7LjaE
-1N12XrtuRZqN6kmdlDNbZ8q2KW8MokdajhuWjlD6+Zr10GvXmNQcu+3uO2nqJ3iUkObVeFYzdkaCDpe9
-qmyqHYPad7Yd3YqqDVqSopFZX3L26JB9P3t0v9SNDraneFjFCnUsk5DAuGVzK+KW1WW7RObCoPpKEwJv
-2osrnqLMDYH/OUu+FAKZleNUUS642CxlwtlDe7szQdcJhgSMyrEf/v+1sgn+2brRPwvnv1o4fUF0d95n
-cwcdFq3ptHtQ+1nvf0+9d41hzlQU2KmonMOeKtftpNsabetGU9RcYejLXBgCl25p1ITXWR7uXbQhOfuu
-az5wrpbHybQeSEVlB++uQbsEbLXN2vv6a/jssN3IpD3v2peeq2Dnwr+fd+v/PnOTWQB3JXQ++Z0Qh6Ev
-mxOt87QAKqU2lSxPUZh2XgJDDXYvlWmbRREyQ2CSJPK+08aGwQXjGU1Ij0HZpxtS7XuGgEyf05R+k4Le
-63Mm0wM+E+b+J6UfVRtN9gfjOCypie0d23lnT659byigylMt1VW7OHY1zSfO/6gsnJKLU08Fy3EgkTRc
-78aB8l60xlc/DqBwY+tMbT802t4UOqeME6FvmsAfuYlPBWbj0/bIxmSSm1gq/g27rnQHMbZXWgKDVwPv
-rwAAAP//5GQuIpgXAAA=
+H4sIAAAAAAAC/+w972/bOLLf81dwvQsUONhNrGT33enDA1wnveZe2gZx0gXeolgw0jgmKpNekmrrXez/
+/sAfkiiJkiU5Lpq+9j5c1iLnJzkcDofDyWRyNPt1cQvrTYIlvGR8jeU74IIwGqJnwcn0ZHLyr8nJv54d
+nYOIONlI/eW/jxBC6GK+QAvgH0kEIZplfyJMY4TRLRYf0DksCSWqz9HRNeZ4DRK4CHXvd5voMjZ/qn+3
+242C8usiDC/mQRi+u56H4WWcfy/hv10BIjFQSZYEOGJL9O56jiRDPKWI0KOMvHmSCgm8imUhOaEPzaAV
+Y5HpqoBiKXG0QnIFSFgeJTM4FJcv8Zok2744lroXongNin4FXCqREYpSAQX0QoR9MZQFJC1fZZhIsjK6
+G5bAjPfC9Vb/P06eoxlF8JkISegDupy9Rpwl4IrtmdA8Cq2mT0SuHIBLnCYyRKNRmZY3eA3DiFEiyKSL
+qUMPQxEHLAEtGfdR107UNUtItB0oH/Sfxds3mpSNBpOTYCiKNQGazGYi5oxKTCjw/UQTZWD0tGGptJpa
+YQ4xSoiQQIELJNkYUYYShmN0jxNMI6VdItSwiRFZIlhv5LYDudeMyyq5b9L1PfDmIbxhXCI1SqsEsw3Q
+Os5/ntiZ/1lyXJLT9DEEpZSFkYCI0RjB5w1TElAkNnNfpkSJYNpXBh58NZHsEMWV1eYg/C5COzpmVy8y
+JTiTqINYmhUUPJ6C5Irw4foJ+sqnjm4f9QQHUk8XoWREvQKcyNV8BdGHO570XXbubq4U9hWR6NMKKIqZ
+MhgrDTNSMAVacrbWVM2uXtTJOHap2N6uOIgVS+K+gqH6O2JLpQcBUSrJR0AbLERBD7IE3cOScUDYLsHC
+fvaYtSD/6TWh73CSQuk3/Nn+NrWyvKOrg/CxxCSBeBcbKW1kZHrSkxNnVFxSCfwjToaZMkWn/ARAK9Sz
+paW8Tuypj9ifPcSentSpvSVrYKkcSqxk6BMm0hoXl2TEQWyUTuoE/9xVuIGl9xw4PBAhOVYUnEOCt3sQ
+nIBEHP5IQUiBYo4JNXPOjgw7Toh8JpCQbLOBuM7CLz6Zn/hk/otH6P/mOIJr4ITFe/BBHijjDYMdLyXw
+jCMhMZcez83Pg/7xiuH4hXZo+m8Q7ObgmXBMbvMi42I6f7Po67cphOdvFqWtwnACFhClnMjtvzlLN33J
+ELYzelC9hxHz6vb2Olvx+uJXfXPfNF9me6Nf7IN/sScBGe6bNIFrTpiSZ+9F3/bLNFDsYHiagKj4A7kz
+P0brVEh0Dyil5I8U0AZ4/tWzRFiJMSFfAY6BD/PR3tJka3cYuU0ybolaaJmQSDKXi2bRXWO5usZSQs8N
+qoeANZbRSjkCmooNlqvdVBz/w9KxWFzNgau9dYQlXMZ9iJnRysYcUwUPRQVAJBnCcZwTZLRYHnvNQlqI
+pP8eVQ2q26tFMcHt/tTQR9R6T3GiBvYzsZOSi6sXmZUxlExurxbT08l0EkyCk2A6OfnFkHoDMeEQyVv2
+SsqN6EPwryuQK7t5tVA0XdUh5oZsDOEeyS1xImCUf5glCfsEsV4uRIh+QyPJUxiNs4bofd2svkyTZIhh
+X6ZJstOyj81mW3Fk2UMRS6lEIsJJGXx1MEQriFPVZiE5lvCwHSrklCLs+KImjsM4YtQGrhhF8BH4FhEq
+pJJJnaqbi+ury/msWdC2wRidzy5ev32TCfocBOEQzxXPw91nQ7LlRW9N3OFBRBaEGVu3wwg8xrBmVDRa
+x9eEDqJrTShZp+safTiVLNMrijDVfwMiFEnWTAT+PIwI/LkPESyVbVQsTIf59d0t5g/Qi5piuVhoVJKh
+DwAbrSL8ETh+ADS/vkOpJAn5UzvJCEtrvYFHQCV+gK4OoG+Xc1Ji4jWsGd8eho+1hv3FWLkxJkMPkMdl
+SAUacnu7AW4MQcaLNlG9XPJLu84MtFGpAIRRDLFeRePSsqUnkPGcPmIJSKT3FKTQxgpwnDtSbQ7cwGXi
+2qBcGIxV3uZsvcbnkJA1kRAr57DLtqPChrNAVJbr5qXh0prpg24IsrVgEIVWwHMS88Fi2+GG2kCUADS/
+PL9B9wmLzBrRyR91CNwjwLwkXEglOpffce6/VJyvssQ70bZHbNVGdQ9I3Olw4kxM84C0ne2hVJZyuXp0
+4gaED9zjpyUnQONkW8QTJEM4Ibg65p8J98SnbbuhdocQ/y+jPfdBSkg3ajb+fKo3gRCjPxl1j+ekG/cg
+tFt4Yx9yIkwZJRFOSgQNCnJcJziCNVCZud1TTcLwob7d5JQYe7HJMCBhUYwREL0Uig0HHI/RPaEbHH1A
+jCOOaczWfeh9SSDpLcGl6tRKJMKbTUJAeP3IRmqCx5OetWgHFl+wp/gaqewnvzmjqiOh8oDjL8qR5CJc
+g3Io3y4R4yjWCQGRvGzcFraRffF5w0GIAekPdsKiP1LgW5Rg+pAq5xtygLv56UXqIcfoIUUcfAERtzDU
+ErswS9E5ERFTgQW18IkNjvZJesiN+DxhaYxe4w2iGVgTS1KHMFCOHBHaj8i+YswWYi9yLLKti4fkFkch
+I2hwukj5aP3Tigl72Ky2qel9QsQK4oy2wm1Y3LxDHCLGY9GHuiHZIQV1mq6hRKlj7zmjsU6IshuNV1j4
+z4d+eMMk+g39cPFHihOh/rqBZTknZ4xGI/Q+2/29wkIf8ldyL36Y0Vj1zhFXUY4bUHlyWzKEPpTBQVAG
+VZR3AnQIt36wMhhrNcheRZkFjxXeNnRV0sZVRJUo9Nju4wtMl6IemOjCVhlNBqMO/05A9rGzED1cFR8L
+gh0UC+227oPAqsmPxxl8/n17Xcv/YYSqsMlobCTk9kPvy9NIf5vRrfpYh1nSQhmMj6rprvnstq1NZ//O
+eiegoA3QaQ9Ap22AznoAOisDugEhOYlkZ3NVNBx3UEENfNAVfNAFvBnew2jvNLArCHpR33Xm+I5a/Wos
+WpaVeCnO9YGF079iVmsnQtXTlh+zMw99UJKf72yA5+G8MRI6XLBVe+Zn+kRZnxDEedwZ3tL59V1dRh5J
+GILVhG9YCCrHCmN04q4DFpsJ2D8mQvcIwI/Tjaz3xdx/PWyK5ldoe4VFLUo0eBG2kDweRpagvWugOknl
+5ZE611GevmC8DpYvL7vOchnhuAWRgeVheka3HoLf8ppsHSxNBDhg69GXXeLwx5dqgtkZ1ukKXXfbDT7o
+DTnoRncwjO5gB91OoKEz7EpMpRNw3xa7O5qidxdkwQAUQVdOgr04CVo4qW6kd4FvjA7UIRvD2TJhiwVr
+7PnZrAO+L64tVgjfpnKTZkd8C4mjD+WYkD3tHEEkJkvG18DDUP1tN6qj5ntLtqfZjuXf8/b+vWpOb1g1
+9zW4i/Q+/039m6DRT38pV2ENf4fHxz/9pfyNv0eVNqZBiH64XCo/vrplUEehK/VD9oc6EkWlfwqs7l/5
+3cAvljLvZ3eF8jfwws1hFz5UY6uKr9XczkigcOvUnubfIGdS5hs/VwHP84VVY/BkiGYD+GK+yO7YNY0G
++735RprbvvietVc+ROXwt8fIMTBzGOXbW00QnXW0BtBIsrxWOtLMfnquHYqqi+E6aPg+gWq+Q5mMRSV9
+yuWpDKEStqpppAK3YptqCCwvVXiKp3ImxM6JXYw4Lxc+OEdHNyBYyiOwlso7BNz7mAkWkkQFDEIf3gVh
+6Kq9x8i55mwDXBJwggMovxBq6NZ/l77qAGEl2nbtXmOxoCWLWBLqVL/SJyc3XaWRWlDlqy6eDsW9DePl
+u92Kb6WO9Ssfbtf61yYys1sWC5MPX6c4a9AEwF58aOxvv5e6OzqdScnJfSqrelL/Juh/YBuiuHRr4fdY
+XVt4Lg3Y320ef61veYR6Lj7U88RVrnSvoel2fIyxmcFTV2P9xt6dbS7p9pP7U2UVzJPA7VrgyQ8vta/G
+qst6MY6yzmiebEyu9FFdA04m9ZzRJXkIG5a2IrvJOHVFP/Teg71pxe2y3ua0q9P3yaq5oZuS3k69h4Oi
+q4cBZ9HXw+sN0737MurGp9pZNXZ4QjYNDCz098tNdzZrkdRBTM6ixgHWzLkbkW9oYqZulrHdwE4GZzfT
+ZXu/aGupl4/R2dnpqKXVQmKZijmLwUD8/fRk2srJkvFPmMcNIF1jqg2H11kq3UhxjjwGGTynv8/u+c8B
+epq/zJ4tav01toIC7/hxvhfgqoc9Hsk8+hpQ3a4cYBFYeFaBxfdl4Psy8PSXgV32r5vt+zGfLtlpvbBH
+CqkAJ1ESJFpiNbFLt2KyQ4gHkOoPwhH7RJ9ngKUobr4hzAExmmzNrXib7IiNfNzc3zFipuYL3Up9P0yV
+KdH3Hlawft64N2tNoi6K2pSaddzONVkjDaOUE6GiOGi0KKdk+xJOEVuin/7SZOkolYo3VII77ZuxEh+X
+9IGD6OkruOe5DU0uN8XyLqOmqfGSs7VZ3P950rQMsx0NFBGXmxCdPNf/Oz75AhZh+tVwXTvx/gLcB18t
+98EX4P70q+X+9Atwf/bVcn92EO4PYOrOzk538N7c4ikbu8fg+ymbu0Pw/5QM3iH4f0om7xD8nx2K/yLF
+qglDQ++OZrOPCMtiLLJaS5XRWvresqE9u5nbriL/OlTTxbg/Le10WxSespaCb1BLwTenpdNvUEun35yW
+zr5BLZ19VVoKnobLEAxWUfBkXYbgybgMh9XO1+0yBE/GZfiyWgq+OS2dfoNaOv3mtHT2DWrpMC6DPb4r
+6kvhaAXZwwGmsCXhKN5SvCaRPiTW139F49lcdiu+dk7VejpnWz/GIV1+kOYtuVRq3zYUCuVPj3yK/eXn
+n09/PqofG5fZilsyQ8t0dUhE7ZKF4UuzGybMLN2cVDvoj7a8lk1QKNXcaj679J9aDpBPW1XbTlkqe8rG
+JbM4b/cx4cmqrRyUtGTR2kvzu/MCyGeIJ7X62MW/l6rBjf3entbgJmWNzk7OmhK4VFowUGkIkPBZHm8S
+TGhD69cgBH6AFyzehugNy+st6MKwIEwJO1sorK7qxaPruukS+GE1Xw0XtyXUFYVlDYr8vw+Q/vV9zOX1
+vm503QrvOLP1s8LQtFmAbMh1rl6SaRpLpepZRaJU9lOprQIYNt/BsWSWfpslBAuTA1QXvAUSHuYuTRVb
+hdO+KOdZqTAHzrmHABdNcb/6wnmGQ6B72DLqVs16AFmqY1mtN17UNclqkGl3zkmumj7yPQ5P9GzvWxye
+p3G+3+X4Ru9ylLYb00dbM/uNzPpCuSuFt3JDvjq50fvWsd0ckd3Lwdo35bTjYtlzwfQsmq2Z9v0Wztri
++ZLxexLHQB87O79mST0DWKVkTx81Fb1eemVASn55kjXklk/755C3JSd3S0zulpT82MnGXVRpd/nT/YMC
+1fozPUMDzdvM/uGBLiaozwFS/TzC63QEh3I6gkM6HcF3p+P/g9MRHMTpCL5qpyP47nQ8TacjaHA6gsM6
+HcG+Tkfw3enookrrRQSP7nQEX53TEfRwOoIuTke9sogju8vZaxUU8w/3ckWRnZLKH5+u1x8rj3LtCxxD
+JLbH5ZElRLoG1cdESc9ZlK6BeiJf+YPro+BkGkymJ5Ppf42OfIYO/ADMeL1YLiGSoRnr3jZ6TtKIbNxK
+pvV/+ZvuqjaRPnd7jtf4T0bxJ/E8cuqgI1SfSiESUoQF82W1+V8mK5RXCSiXl92iNNtO7ZlmRn9KNZqN
+yaYerq7oploDrjYkmg+M8pGVHWAqwdnLjPp5SpRSSRIkV5A/jL7NX0I3D6eLkrBuAMd+WelS0Oa1fiWc
+8FdMZC6rV5jGzgB/DRLHWOKw4qbZedRccMdlyhTV8kLIzwQMnJKeqmDcwnqZnM5hAzQWb6mVFYclcKCR
+ru9sOMIJ4lnJmuzWJ5LayJpbjiqISuiDfanflqH8MbvmqZ//EUhyHH3Qz4hyyN79Kb3IKA4p8Fo5E0dg
+rh0eeQqGqPYeDXiCEhnQekAhg1vv1AVy0Aw5aIQc7II8dYWwg9SdZAZeYH7qAncI+ipslRZkdU228hTQ
+j54hmL2tmQ1Cd/jZF3ed6D1KhT7xAfdZCTsTQvRbZVCOy0YBvd9pAbMScrWacSVLX38esKlKbKlfUSI2
+Ztq+pRt1loEivMGRfj6Ss48k1k+OMvOoRna7O7vXXZTij83uAwkfpiuc0mhl1JFvnUwZ1dEYXcwDq96R
+m0NSHSel9wM9YDwQxtnmsuhZAep/7LjYK7fPbm/nLry40IS/dN6ksbxR0WJSrlkfeurYHzVtoYqC/bvq
+cbW6yr4IUJc9Z7eMbtTAoqd+/lHbfrHK7K5jq947hGl78cEdOVTdxRTsKaZgTzEF+4kp2ENMu9qUHKGG
+GVusErau786ZWhhJzMG8fRLXX0kdZ3aPgG6ozKmtS1F2VCu1bQk0zv7Msh0Nk9XOIVUvD9zY2hru5pLF
+DT1RFgcolNJQlDjTT8PnLop69DlXr0M8SEDBngIK2gUUHEBAnqK//eOJHiDtVbhaCzQ3cOIUMvbJ0Ft+
+uSbKhiLN3SX6CGnKvjrLg6QVPIa0gk7SCg4jrcxAm/OAFgvZVqPVNYQW0tYsS21VW1vXRTdVyvsuU2dX
+y/9wUm9DrzYT57Ak1AZX8k168WO2Pb/nJH4AtGYx2JDGA0hP+nVRjUmvdvlbTLj++lJzGV1n21XVT30P
+1rHmbtMGyVGKr5Z4eQNBRVOwOa87fhm3QCu/1lX4Hakk9CGLoLy+u7q9fDe7uruotTynwuT4ibB1bi9u
+3jUdPNxeqaehm7Yw81RItm5i8iUmScohP3EsPfntq7PsKHKmHiyMdNxklkqWVWMPy107VmhuUqZ6hd3u
+Pa0WsnfZy83w52oz/LneLKuPfBlnRbXs9vX4p7+KnfTf5r8yK+ApoqWLUt+8yYBgTkP8SYQEr8PQ1t+a
+RfqZ7Mv475CzBI7xp7wc/ET/AJF4jgsRTnAqmX2ZvRyVPZ796nqoLxn3S/73guZRJRhgFHJO1kCNqYdI
+hJaa0N0K+6xuPsx1N/vGx/WdxdoS+G0bIHlP7+lHXra/VzzYKKNW/2wSbdKRp5Oh1IzSWxVCVEGRyrBE
+xWvrpmFhDPyjvNj2VEAanGYepuZIuz4dTc9qtXL3jZxal2sOsbLtEL8GFb9bbCAy6dZeDL4uRhDF6JmZ
+5+zn13d3xUv2Ru/myYTDq97geRTtm0f5n/oAcN8s+oJjwKCtDQP3fYzDDwYX26MMieyZ+Kc+KOpPRh1u
+aMyuXrjoroE3YHTX2St8D4nnFZLaiyRaL8c//eXEhZ47f79Mk8SzDJfBDLxk4P5HhseTnpN9qh8uDDu1
+7luovvup85c/T25pkuHU3k73s+YOECun0Z6j+/rsJv5j3sqhcuaneYjYJftOGuijh75SAZMzpN74v89y
+hkJTTPYe/jEUwI19wNhMSTGcDt4fUBT0oz8KwlkqV4yTP6E13abNcIVo9I/R0f8NAD5zB1JGnwAA
 `,
 	},
 
 	"/templates/src/ecs-stack.yml": {
 		name:    "ecs-stack.yml",
 		local:   "templates/src/ecs-stack.yml",
-		size:    11590,
-		modtime: 1634116747,
+		size:    23313,
+		modtime: 1760000000,
+		compressed: `
+H4sIAAAAAAAC/+w8f3PbNpb/+1O8qp1V0jUl2XG7u5wqN4qsNLrajsZS0tnNZVyYfJJwJgEuAMpWs/7u
+NwBIij9FOc3udW+OnWks4uHh4eHh/QToOM7R6Of5AsMoIApfcxES9R6FpJy50D0dnAycwV+cwV+6R+co
+PUEjZVsm4zmMg1gqFC6QWHHpkYCyFVAmFWEeSiDMB8IgB9nrHh3NiCAhKhTSPQIAeB95U9/+qZ/FNkIX
+Rj/PXXcyPnXd97Ox6079rL1AxWKNQH1kii4pCuBLeD8bg+IgYgaUHaUDjKkvykPMlaBs1Yx3PD2/htuA
+e3car1qjxn2cn57nYaRACbJcUg+WgodA2PZ+jQKBMqAqh3xJ4kC5cDLomf/6J99b4maCbojCeXzLUMky
+jRdUqh92vLBQmh0vm+mOLEaQFqWmJCEeZISe5pQP91StLedBrYnKzSrPuVl8G1DvS9JmEB5M2pILAyDX
+RKAPASc+3JJAEyoshT/h9oqE6DYPKeUa7nAbESogluiD4mblpDSo0ZO7yTcK4U+4nREqXDcZzw4+itWa
+C/or+u8kCvlOBA10vDX/kgAcGDGIRQCKQ4SCcp96JAi24PN7ZqanySUZ3ps73EojWW3Cm8hXp2NJmyZT
+MtDNzFHbCLV0pxwAxTWTMsY3caZpeHXaC6kneJWI0xbW9GDEuFqjyNGiidNUaO0CqXpZCR5H4BEGAYmZ
+t/4NjHnxO6Tp7HdC01t2jiFh/isicUwi4lG13SNILA5vrQLeaRKjWIhAIME92UrgzPENzhIpV6ZvlZRB
+9uqSsvckiFG/K1A3Q+EhU2S1T8ijDKhIH7nlG8u4WyIRvGSWO7ozgo8NlEBp38uIqwPncDKon0X2jjwk
+7zSkeT2PuBoFAfeInsJcCaJw1cT7N/zekJOflkDw1lwisyYpv4+NrBy6mSNBPXRStjg8UjTUSimD1FTe
+o28mkDMP+nHae1uwzwBwIkG5oKoGWNMjlWPGTnR0SDOHw201TPP5JUQpuLVEmn2T8Xw3PIwup4mePNYy
+wYONtlprZLjBxF4p4t2BtyZsVW9U5vNL193R5RoW5ozpNNQiXbKlybL0yb3sSxQb6mEfPdnfMYaEtE9C
+8itnTkBZ/OCc9gV6PAyR+ej3qcZ6Q/2MM1O/gSV5bZPON6KsIEtSv9Q/kPiph8QZWqHLs/2JmueSPMzp
+r/s2dEgeaBiH9UqH1+u7pg2auGHnKKlA/wBN51vInbogS4XiaYO+SGZKWdtMKYu/1ExP7KDpFBdErFAl
+CnSPdgljb50ur2e9+K7czT4kjKzQz8zOHWJkHDuzPfR+FLAxCgICJBuENRJfcB4aH4PhPSgi7+RvUqcn
++9TpOffuULyJb7WPxgq+Ynsc8FceG6/RdARuN4DFCG/i2yqB3W5p1ElIaPDkIVH3AuL7AqX8rHFnRMp7
+LvwnDx0lHfeMesUn3pq7oESMzaRMxvM0Pnxi5GWZvUxVbyp2Fu0FX8mIx8rK71NQ75RakODQm1lRZgwt
+4AoiEqFQQvMemR9xylSvWVGdE0V8vhpF9Cfcfh4hRqFYNDCaTXWgYlRtLNfgW8bjBpkJlRRPQffQNJ9f
+jFHocNgjCqf+byDLotYYwduhLMdkbxaL2RwCKhUyFPsok8GMB9TbPlUaFhdzkOjFQqubyKBIhaOWhioJ
+k4tX8wSBJcFZXMxPXjgnzqlzOjg9cQbfHx2NOfOpHjXxZN5JfKNUJC8StDuyv7riCj7AV5O/xySQ+q9r
+XFY4fwydDnyEj3b2b4gs2dt6LAao0rUhkKpHUQDei+rFU1C92Ivq7Cmozoqo3knUPm8bhqrPf6y1vMXz
+NlZRnOYo5tr9Kka+iVXooCedJRehdrr038ZT6zQrrKSfIWDXnkh0IlM/6kirvksBJNNf5lcxbVEaJwXK
+8gx8bu3rnrEm43kZsGjxZ4JvqN80uzJUEppql3HqV7tMl/ABOqlYd44L4pv+nbmA6VKXcn358U3T/pxY
+Cv6fnDI9+nE6bLEDfNybvWrGkodPkVxw4r9KUk71hOchqn0OEJJG8Cq286t5vdz8iGqkVAG2lwBXsbyO
+g+BwNHU9qzjfcKnQ/xtntdJSh3dMGGc6/ZXrep4ol8ViVtW7eZ7lIXZ95tVOmVp3Kxq9EfG8iHluY61z
+Kj2+QWESgDIiHjbt+QbwFmz1KzKPb6Hzzaed7nns6dRA0Dk6ukbJY+GlofdkfJoq2ZngSxpgbVJ9Orp0
+3RJgBjcTXHtAtBzOz4hau9AvvLvmAUo30++jS/0i3TbJz2YKdGvrsCMp4xA1qLXb59yLw0K4kj5zRRTW
+N+nHgclyiZ5ybc6iFsboHebRiARuA0Bu/fTE0Tvt2XCb3Muex0P42NBx5FkRlEq6u0m1svjSBld28iPB
+ZJUwB4hgLrmXLiWha/6wPlKaInAED7A/MmROxqdjzhShTGsa07zkYjI+NdSkC2eGK6xFefEsQe1SY8CM
+XGf2v9R1B/ZPXttkBfYsrANewGP/nihv7c5idYlKUE87+e2dlqZspgew7ustGh8k3aF7EaB3mvVakJVs
+AE5xudD5tvNPZkRHB0juWCBR2DDYLuO2kppbF3w1MZFKO3Q62Qu+miuBJDxgyqmQGwTf6v86NcqoZnfk
+lVPm5qXuVa2E6wDTdVOQViG/RoVMs3TKzslWunByVmgv+HuWnK4Zx0iIfruLl5sdvRx9OSDXLfdoJff9
+bGyNdFo0detcqKIWog/op/tXloO49MkAzqlUgt7GzWJWW1soOvn5pr04dtHASKfz96DcQdYibEi7Jza9
+trGC58Lk49Jaev3kizBzW/a0eJs3ZbHT1E89x8Lrxt5ZOT/zwwr9ehdEoUyL/qXUW4HnGxSC+nUbbbfh
+CpXHauy3p+cupiiEsJ1j+NSG9RQeEye+Y/bIFTf+Uwc+Pnm8F4eM9+LLjXd2yHhnh433NQiMAuJhuSYU
+CU48RTcYbE2lAtQat7Y2p0BQeWdTywqFiE3qpYA23VDXmBTfs/gvCd07xyYXeAxLEkisEGVSxwkOiJL4
+EgSGfGNpU2iMEnDmIRCWEQ5rIoHxUopYP1d4v9NIFgH6rwUPtTLEKSslJyGXbbd8TH4VIchDHoI8VCDK
+ZQILWXpb6KEtep1d+qSPLrig9f9x6uzrhEQ69WOjwcmKKBwpu1/tnOCxGZs2cp+N7etiDZF1VSpNfiYz
+ScUBVKI60hrXMfyCntyC4EHgJOnaX8DnSRU4N8b9mgYI94QqXS3QycTET5WgOIRJRZaK0pobX4RyVmd9
+UjdhTleszndf0BB5rFyYLU6+u6w0j3nMduWRr+END3wJCkVoMsOFE00xUzSAc0Eoe0OYH6AwIqrJ9vN0
+AwYSzUEgg3SxQ3ZBl+htvQDfcH7Xbt8L4O3xUskbyPkcddmhgoVJB1oIwmQSMeeOdem65M30ar4YXY0n
+N4vJ9eX0arSYXv1YwPIGiVC3SFTG878MBgWIJCl7jdKkZsdvrxbTq3eTozKfruOG6NF6ma6rAVoZUkgm
+21jaLF7T+lIGhVi76GSaoWdEKRQ1tjr1VT+AjgZzrKsxCj4qQgNHmWl90KF7pu6dlDLcrUnitDciqrfH
+9dLwoVEeSuhtgaVWeWkHJL8JaoYfiZy/kYftjURyuiz/dqYnLWXBCcr75CS89Ynr7sDa90IScge265Rt
++B2+jplXMW/py9xuaZzcLkWQFGaKCYAC7NwIRIERJQHf8eJrmKOyJ9K8NEIvnMo6vx5Nr6ZXPwJhPng8
+jAJUaDsEmZiQnBmlSqumBHlqQ3twwdkKfLsHBHpc+EBgnW5bi50IsQWuzTAQU6KlbJO4vr3Kyu1drwq/
+m1artAZJ1qsYJz06hu5S4KdVsu4TbdWasxe9k9OiRkqoBMp8fOita9Y001UvSrrKZLBqhfiaJ4tXUA9s
+QwVn9ZmK90RQchs0+c7ji3fzxeS6WmkoAHG/Jqj4G41e62Qf/KMWMw0jLhT8t+TsGLg8Bs2ufZC3XPHk
+gEL5QU/C0AL0vIAiU8+66Mnu81rovAos98q1NfS2+/am0tO+7z6vp9DHJSRr/Mxs0GOzn/BBPW8OWqwS
+haHd0h+69ne32ZdPPBwYApc9tMv+oZus4Z5+yf4cwqdGEP10C1bfpAjchMgPNW0fj/djq7MDOYS1zW04
+Myqsml3wO2R1ROab9+B8PGpsIoJpoUNP9nSF9yZTjzeZ2X62l9ZksYbJv8ewpIH+3UUvS5hPfRgOoQt/
+zGaQy6ZP/e7H5x+62cDpe52Q7X5spty6uqQ2bZt/snkk0/ST/NjTp1o73QrdcqhpqpuR3CO7+qFLYPm4
+0m2l5lYguTto9jDc4f0waKUjhf3QlYqoWHY/wldD6KZmsttOmmZ1HPlE1TL6RqPFdnY/heXHYGkd7sh8
+vncADAozFTFjlK0W2pKbqKX7Ef6Ya4+Q+eX24RAGX2KZDCmJKu2tUN0IDAnV5Nxog3JD2U1Ig4DKZ8/h
+B21JBwcMm7MCPeuM3GTOzI1VljeZb/Ls22/tq+eteBPb0aPG6XuWdy6G6RyWycsbpkPwg9Z5mrlB2tUZ
+dk080D2GGdnqs/tDbWN7fhxG0tqe5+2UClRxyY0oP5q9PRkgRs9OBs+Pjg5hZuogVtj5rKSZbRw27KZx
+WPcYdmyu+Hr/90t6VnL+bap6P8+tp/2KSOpNHtAzWfcqFZWK3m6gfJHOrPW0cuejhGjPmh20ck9ZvwKj
+W3eTA+hJV1fvxxUNfGDntDj12QjeGZNS7W6YcgCOfM5lnGzj0qZ9IpZro1lLOLJMzV5k7UXHz17MAwLz
+ZmpsgJgVBS2mJF68xhXl7DH9OfI8bQqn/qObqny3MbJMY3GdRJbV0+5LqsxLm+Mz90QYog86S6qjHMJ8
+4CzYmtPQNp2EYaS2pXtUzQeyCvfO5q5bhmxVr1+bw7MSPKLzt1IRoew1BvTksfZIvXUWvdhbChbe58X0
+ei4M9yJnT06sHD1U59QEaRMjh+Qmc0ozgalXBXPjXbkwuRq9upic18LYrFYpgV97Jr6JhCyHw9ksK13s
+Bs3SOUlWW8uElRe9DjxWQLIs+jZCiCXWF0isqFiml+VA7hGZ+h4jKblHDdWyVYoSHC3ZiApNaW6x3AIf
+61LAZajqTaf0cao7pn6go+p6/4x0td6l95sqtOULnw2V1SaGFcHPiSI1/kz+aN/U37Gr0FDjbFxyRhUX
+jWI/YTqx5NeUvCB3gvMzjmxWUD2xtDslYeOJtPxTSJFWj7FVEm2Qu/hryUh+VaD0nY/61dBP9zVzXX1i
+4PuzbqLt/tFogL7+qn9LWf+WyDU4D5tmU76NQ6vvgwCcrS4GON6SObecK6kEiRo76otc5nKXHkd3oYwq
+cDbg2GPKUDFb4DgisYklETQt2g5C0SoePrg01TRwEL75j8MoqFHjrWRcoiJ+ZYEM6FgfrHqdHaxyp4zW
+OJQeZ0vasDG08OzJUfRReeYCnc4E7MOTG0tpr7ZNVNJnMp7fJEnBYcGEHtTTGpSb+eztYld4SxMHw9q9
+Xo/mx+nV5Gb0bvHmZvHX2WRo75U8ue/5aDEafuqslYqk2+/bdLrF1aO8vznpd9xPnfSuVMftfPOpcvXq
+sXPcSe8XFSHS60oawlx+KjabW1SPncf9nAt1fhw6g8Hg+8GgsxeU3zMULgjO9zvA5hZ1C1x/zUN9E/PU
+0bPvJ0zxlk+SppetC/L75n3C0JQLhzD1IFi7pt3B4Gww6O7fyp7grLfmsQi2/dInFL7svi6YAoxb4RVK
+Bc6vpqJV+WTEYwf+8AfAB6pg0IrJi0WglTENkClwlo0oX0JfhVGZDa34w01tv4qIS7l+Mm5vHXIfvh8M
+vhA2fs8yEXJ/M86d7vjTv0p36IvZhPl7hHOJyls7u2mYubUKs8Hqtu6J5kxi4r04u/PQjjl3e9i4T99E
+7YtNFPzww+Tta3hppyW30hrs/oHW7O1sMX17NR92HD0Vxxd0g2JI7qWeGNiXPFKQvEm8lmHRa6mBM2ts
+jXt60Pex025b375uhZFbqTD0VAACbRyfTLWxZ3qr1T1qU0ZpaG8VE4PuN5+K12ofu51/1mIfpDH7sRSm
+h50ziJiB48N/tXbUj+NoezfspPzoHNwv4fQw+WDJod3WXCo9JPyS/vXLwX03PIhDHPY3RPRFnE64J7l3
+5xpVnHtxINJVoGPhgNzKfsqCA3tWxAD+8LKsWVOUPR3Q9AK+ahbI5LryZ8lj4XL1v7k4fqZYGZHyfYes
+kKl/hTBGcPKn097Jn3pnp70T988np9+Z//VjPzoUBUJ3MfpxnpZC3ULY030CltFsevPT5K/DiiQcimMD
+9Xuq5uUTUEaCe323r1mb/C34E7p7xl6kCORW9pcyeXk4omRbJTNwMgmp7tUEsrRVD7ieWk6HFcBas2EG
+qnD0Mu1vfaLyNwRGF6+Amq8VMQXcJByLd1bMreH8NeF8azGvxlYCZf2hRZ2bVtzjgQvKq0vD6PPjMy6U
+C3+uc8UXfE+j/sLgNHIh/b7f4DdScHb2Yg8J9a1VGtJktOXz7RZIEOwOXCdf+MiOLSDx1mkrEN+X2TcV
+QMSBLYEIHivUf1BVEaZ6+QmIVNTbwVG2eq8zrLmOrRI199YYomuvKDBUztIUXIowyUXvmsvczfLSfGes
++VZ2Q4eaq9v195gPYU75inJj4jlH5a6gUstbg6ZOgHdCqemtS9Xbkl0tr+xklvqKmLZzEWeyLi/1WgNc
+J+3jvbk2W8UZ23jsbHDWZPrHNmq3BOgjHf0oILSpgniJUpIVvuK+vnXBMzEPdXhjan1UgsC/xyjV3gvl
+n7N6h1xC/6IrXNYPxSUuatfdh1kSOU5/F6By3zaplYNc+47K8jdR/l+2VKU+qCNNrX61Kg4lBhuUYL4g
+KzmYW1oeYbCkzLfq2X77UcfDySgvez8kCvyl/TLAod8syH+OrgTtusl10HMms56tklo8u13zxYKSTa9+
++ONf5I3ob0qlNSaQBdfkf9nv6J5091j97vfffffiu+4ey5+Sqn/XsHSOwfIalyiQVSWh08DgZGqd3HeV
+9EFD+Za50ClAdg5bi4ynzWZ1H8+aubWPT/YyRqkGW0fH/wwAM03xHBFbAAA=
+`,
+	},
+
+	"/templates/src/instance-types.json": {
+		name:    "instance-types.json",
+		local:   "templates/src/instance-types.json",
+		size:    54334,
+		modtime: 1760000000,
 		compressed: `
-H4sIAAAAAAAC/9RafW/bOJP/359iqi3WwIPKsp00aYUne+d13F2jTWPEbou9vUVLU2ObqEQKJBXHzeW7
-H0hKjmVLttN9wZ2DJDL547xxyBkO5ft+o/dpPMEkjYnGN0ImRH9EqZjgITS77U7bb7/226+bjUtUVLJU
-u55Bfwz9OFMaZQgk00JREjM+B8aVJpyiAsIjIBw2kK1mozEikiSoUaqwAQDwMaXDyD2az2SVYgi9T+Mw
-HPS7Yfhx1A/DYbTuL0kxWSCwCLlmM4YSxAw+jvqgBciMA+ONgsFIsluicZxNOerOPnYOsp/jjEmlIXU0
-QdkRwDjoBYJKkRphIlgyvXDKVYvR/bNiKKSCR0+W4y2u3pMEwz2E1QK+4iolTEKmMDIGJZSiUpY0UvU4
-ybUavMXViDBpHww/x7yX6YWQ7BtGHxRK9UHGNXJc2/8kBh96HDIZGyFSlExEjJI4XkEkljwWJLLikjXd
-z19xpWAmRbIl2lhLxucb3GYki3UInudEG+YqWXS9cfQqReNohQWMXJlCmAlprVNnmTr2uttKGJVi3dOL
-Y7HE6COJM1SPgpiPb9CccLHbWqaxblYJieMKNEYsS3bbYyLnuNWcnFTDk5M6+F1Ne7e647RV01wHr6Vz
-WtfRaVf20GrWtIY1rWNN61jT09ar6o5q49Ea49E649GTOs4nNZxlNWdZw1nWcZZ1nGUdZ9Zt1bV36zpO
-6zoKFrbnityN2bd9qzYhdyzJEuBZMnWB4jFMaQExyThdbK3Y9xa7u2LPHNNLVExi1CcpoUyv9jCPHBJo
-DgUy0yifxvQk15TxQ5oynv1VmnZyTQX9ivLXbGr2bF6KHTV724ZMv4nMRhE7EISLTo4i/JpNd5k2m1tc
-Bwlh8ZNZohkFJIqkiVvfw3dElFoKGT2ZdZoP3MP1vRjQhQhBywzrRRn0x0WK9QQZjBc4Y88se5N/UUfG
-kX0n5ioVmZ6YFaSfQrqIyi2IcxrGuTXjxLQDziElKUotje2RR6lgXLfqY+4l0SQS817K3uLq+wSxC8yR
-gd5oaBIXmypkagGRMzzeItcmZ9GigNbIdJ3pNNN51B1rQr+WswEblEPwkCp/JmSCMgzNszJQr37S8nHP
-bnC20e/wY6SZZHr1ixRZWj2kBFnPof1WTuW2+BSgRqPxA5DkG/dJwvxuu3PWar9uEZ8k5JvgvtFApJol
-Jn9q/ABjRFhonYZBEAmqWmSpWg7aoiIJevZx0B8H5sSgdBDhLcYiRTnPWISB22I+U8E1YRzl52IDai10
-EjeuSJoyPs+N3Ps0vsE5E3wielfDR00y5SNR2u+EcA+9q+HwMgQjfOd19/T8vI3wsAPtbkGn0QmenUav
-ytAlVlA9n7Xbp9POrAK6TfXlGUanr0/ONqCYVVOlr05OzqPptAylyLUk8Q46ijpdnE67G2iS+lxIvai0
-BH01bXfOyOsyXomsBn8WdbuvTkuWK+G3FT0/OW2fR502PDQaN6hEJmmRjw763SJbHkkxYzFWnmSGvasw
-3AKucSNpPEaz7Rx3RPQihKDUdiNiVCH87hx72LsyDfBHnre7r/USmN6DbHtKZQka6EjEjK4uBc0S5LqM
-yrcFjdVdLjsZzGZIdegS+UqMEYNxylIShzUAtzPIW0bRKI60my9BsxipSOCPmoE96nZIpVX4qNRBE18R
-TuYYOeV7kqtdwXwgkodkqUJGktA+pBYeKCeoL0WM6+2h2y82gFyPmZCDftdKU0ycZVeai+3JcwId9hoL
-s5vh+iC3NfQR9jfPbT4DeybWBxqLLFoSTRfhKNNXqCWjJhgeHjSzFRrDwAXEKdo4VazQvQSQdtejJmSu
-asAFrRC8f3l/syE8k0iEfYlEYw2zAmqRo0y/E/OBjeiH0YWy78R8rCWS5AiVCye3BP5lfryKzahidWxu
-TutUoAjBlR5uEjH710XpQ05+gxq5MemQX5KVCqFzWuov5QROnKblYz3EtD7mlb1Mi7Gr2NXLtwEqfTlO
-3I+j/n8JjsN1fa7WaBUVumOh3S3oO5t89AWfsXkm7ULZMEdFb3kXzI9XDp1/29on7zYR7lsJsX0udMit
-1tIIsxSrbHMPJisGI/+LIrUzuVqRT72wpidzorGnnWruRLER4neoGe/8bmp2nTLB3T667Z1uCY3ZnFfF
-tQlLUGQ6hNGk8/Jqp7svMm6PnEXDhzQiGqs4bXjijYjNP4fd5XnFeBEN1JCv42lnF0jufja7sZvb3f4R
-yRQaDYz4FdJ/Ikxf87IJVH68a9T45eElt89d9yQygjJjuWwaMzpMe+4IHMKMxKrsqqVzxWOCVWreSjQK
-c14JzrQwB7StQ6wFJWSOwyiEZ28Yj4b8iqTw+1aq/2Jzg3LtzRcu/6xh6azkdtmNpjKWJNuZaXEa6tZl
-optFcQcuStabiA8KpYnQu07WfMPD8Gei8Oy0GcKzcTaF/6kMMz88C6aMB1OiFuDf3VbH6lWWuIpNHIO/
-ArJUPp1xfyqEVlqStHJQIFIdkKWy9A2ccabBvwXfHU3h+X05EDyA78vcW6t803abOSlGuhl6OI67sv4P
-PsLz/zhOhIqIdFCEK9Qk2pkRC+2bTOnNOlMKh5xVpHTUalydnxgPUfWpS4CaBkiV+W3to7PBywTvvf6x
-+Rn0x5/77z6MJ4Obi+f3jzWDh6NGDt7/Mnw/+Nz7MPn18+S30eDCVUKePPayN+ld3HsLrVMVBgHjEd61
-HK0WE8FtJ/DCe6+o7nmh9/x+p1j44L3wiopYGVEU2AzCluvK3bbu9+A97Fc5EZHJ2trt9lm77e2FiiVH
-GYIUQu/FzW1CtB8XLESCAdKub7QPcqPQ2ZPc4KeDE/J/2/a5QQsrHGPUo7BuTpvt9mm73dy/BqkUvLUQ
-mYxXwdYl4F+7IEubN2YH8RqVBv8beM/vdy89Hzz48UfAO6ahfZASzWRsdlEWI9fgz2pJ/gSBTtJtMxyk
-n9xWjttxcaUWT6ZNF4mI4Kzd/ouoiSVfu1D4p2k+7h3n/9TeQUWSEB7tcc4ZarrwH9Wwuh10Zks1PLgm
-GrVk8pzDf6xM+PYEfBzfpy+iw5NNNPz734PrN8avjVpqpVykDY6MZtejyfD6/fjC840qfiTZLcoLslRG
-MXCNItWQt+TpxkU53ajA2Tl2Ubk4cj94h2Pr9ZvD9oH/nFxfXocgMRG3CF/yG4xUfQFhXzZYIMxEHIsl
-43OYZnNgCmbsDqPwCOI+FNFkzvQim9pyvkncNi4CyBy5DphSGarg5NXrg2TXIh5E5mXCYoTEWJCo3iOL
-K6b9mpl9Nvc/z+25HJrP78t3XA9N7+/y46OCQZApaUcUqmcc/Aj+++BAe3T3TSi/8Ap7eEePk6g0kfqC
-xEuyUkcPWwil7QXil+Lpy9Fjb0WcJXgR3BIZyKxQuKUE/RraKLPRcCTRecwilDGZqmB963jcyB03gB9/
-2g4aBcmWOWG1YjGvd8j87vC7/LF00/n/3B2/062sS0WR22H+CWdMoXPebXXOW6fdVid81em+tH+CLEqP
-JYHQnPR+GV/k9+dh6SjWfAKV3mj4+e3gt4sdTziWxi1Ur6mKxieQTKWgQRgY0+bPUjxhOLWhsCCgViqY
-qbzxeEL5sso18NcesrtWi8v78lKtuUDfeaWydIl+qIxmUaVXDgb98bruBCon5vK9ctnbvs+6rlZv1adL
-Ugz53JbmKqq1w3QkhRZUxCFoWlX1eSNFMhJSh9DsVB2SJiLvPXv58uRlFaLPIjlMQ+i0W/Yn6JxVWHSM
-8ewGZyiR051bVq/Gvrlm3sZrFinySF3zELwS0jtuKtYmrZ5GW/vbY7J6Y+0z09hVc0uKVcrxvwEAAP//
-Myanp0YtAAA=
+H4sIAAAAAAAC/7Sdz47byBGH734KYc5rQWx2d7V8zD3PEDRcE6UBszfgzgYOgn33QLsDWKQp/ump7+pD
+QcP+PrHqR6r8v0+n08vX/uy+f8vj7fXly+n+L6fTSx6//uvly+nle4r/iP7ll7/+dXgdfh3/+/fyt5cv
+py708fL+7//5+u/ff3v5ckqfTqc/fnmv6Y/X7C/i5jW7+Fg0HS8aO+/nRXv3WPT450w/VZwUPP4hJaZ5
+Rf+jogcOyBMH5M0OaFLU+IC8+QGFc9dwQtfUX/y8aJpUbbicnRcf4qysuMeyruHou2uMlzQre42Tsg1V
+Y5/8c0hDE6ROYlqBNJyvDeffi0srkIbjkPrLNT6HNJyH17f8zfiIjv/hqbu6FfAzQ34+d7GBpr67yPzT
+xunHZdjPBPwZoT83fUeH0MeVm2jI5vxnAFaFaFUKV6V4VQRYZYhVBlm1Z1btoYWYVai5oIhFgGV4VaTB
+sKdViRYDMKC2obr0USeo1hakXBcua0jVFqR8f7msIlXPV5vvgH5a9XDN0MsaUtUIqdk5NZz+RcIKU5Hp
+WyPUt0aob41E3xrzuW8RK7rOz8t2blrYN3wR9NfedXNou6ubFra/D0Sk047mnXbMx6XddU2tbwTxtiJt
+HodGZ28rzj6tuq3s7Xz8s26ZdTv7wzU3Mb2d0+Gim5TezkdLbkB6Ow+vWn4fDlR0F//THz6ruIj9hw79
+8KXcYl4Z6BWiXgnsFeFeEfDVnHy1R18J9tUe/srAXyH4KwF/ReCvCPzVHP5qD38157Qw00SBpokCTROF
+mCYKNU0UpOkvSNNfzJv+crzp33VNzZv+opBcStmllF6K+KWYYMoYpoxiau+YMpIpYFmFLKuUZZWyrCKW
+VcyyylhWGcuqvWWVsayaWyZM6ixQ6ixQ6ixE6ixU6ixU6ixI6ixI6izmqbPkp4PdymmtTnbyHmR/vp+X
++VmZfxkgabYwabYAabYQabYQabZYp9linmYLkGaLeZotTJotUJotRJotSJotSJot5mm22KfZQqTZYp9m
+C5NmC5RmC5FmC5JmC5Jmi3maLfZp9l8lzeE3j8iFicgFisgFisiFiMilUBMKEpELEpGLeUQu7xH55zsJ
+1hRgg4p9Ap+QQSUxg0q6rYj7vOzWiSVg/km3FW2f3ws3EUjEXJWIuSpZz1XJfK5Kt9UvgQ8RtfYd8LHz
+t71537y2PR1YvAg+zQpHo9Quzj6xs7l1zT6ut7l1dXFaNhk1Mb2b1rV5s/M6+7Q2LczDeQWGr8DQ1fYL
+2s0rGwBkQ1NrKCn63q18ydwCIUJANAgArkjzci9r3WXca5p3A/ei5t3Avaj1JHuvaXsvLK5F0uh8fH5G
+xbXI1Dl/dSuHVFyLTc5fU1g5peIabOo7169c0r7pW9pfr9KtnH3pgZPqkZPqkZNqWGzShdg9b69Lf7yd
+CM67tPLrrtLb8+TbsqbF+7NP07oNoC5egjj9vM4b3aSvcVrX5iY9vQgtz9m7i09B4sqD9ntlb3T776ZX
+IRn1gI92+Ya8aelWPS15/NeTu66rTVv5A9mh6bt1aXtQeqxpsZXHPRY8/obBwuag7rGizR6mhyvpz92l
+sT/9qeplUhaYfAZvP6IMHhglhoalUQt9n3ssaD1FDMzQOzBD78AMvQMw9A7EcDogw+kQrDkN9pHPEAD2
+MwR/hujPEP6Z4D8jAmTGgGyuQCZ4VQpYpYhVCllFmFUGWoWoVXtsFeCWwpailoIWYZZBFiLWHlgleg1E
+gkpZUCkNKuVBRUSojAkVUqHau1ARGSpgAyUD5QKlAmICIwLkgb0GiAWABBGaPyM0f0Zo/ozE/Nm4AW7x
+QUnnpoWR1xmGiEzMkZmYo/nE3LICbtdFtfe26WXUbW2bXujYtrblhY4tuVpe6NgkteWFjm1Qj7/fucFp
+w/udC2+MdrOKx1462XPqJm/xTEoqxL1C4CtBviLoK8O+msOv9vQrgb/a81+gdq1A7VqB2rVCtGuFatcK
+0lUVpqsq5l1VMXpfan5R7buqopRfSgmmlGGKKKaYY8pIppBlaq+ZMp4pIlrFTKuYahVzrTKyVc62CulW
+Kd8qIFyFjCOSvoIZhwmH+cboxtkGyUa5BqgGmQaIJlCkLlCkLlCkLkSkLlSkLlSkLkikLkykLuaRetN+
+u/W0pnm/3a7Dsv8+YKJ6YaJ6AaJ6IaJ6QaJ6sY7qxTyqFyCqF/uoXqCoXqCoXoioXpCoXpioXsyjerGP
+6oWI6gWI6qV8/ue31+9ED/RemWgr3ksjvcV7besG472s/X0betYi0LMWgZ61SEEYptrtwngBKWFvQ+Oy
+tj0YYF038CgnMV13YrrutnVtm2eWgGa+bV3bNgSJGBISMiQk6yEhmQ8Jrfva9iDVtK9tFwDGPdgIrD4Z
+kdUnI7L6ZDRffTLarykZPbL2ZvTA2Xvk7D1y9t787L392QdkQ80YkAU1Y0D204zBfj3NGIgdMmMgVsiM
+wXqDzBiMXuOenpL19pgxZAj/DPGfIQEyYUBmFMiMA9legkwQqxSySjGrFLSKUKsQtgpxqwC4CpBLgUtx
+S2GLUAtBCzELIKtEx4FoUCkPKiVCpUyoiAoVcqFCMlTAhoroUAEfKB0oGygZEBcgFSATABEQDwANIjSJ
+RmgSjdAkGolJtHENwPZK27FxD0AXxKXoV8L4MTLTc2Sm52g/PbesAth3Xe3lbXrUue1u06PObXVbnklu
+Gdby7HAb1paHh9usHn94uIVqw9PDheeR3azisXes9py7yaPoSUmFyFcIfSXYVwZ+ZehXe/zVnn8lBFB7
+AwrUtxWobytQ31aIvq1gfVthuqvCdFfFvrtC/ouHMRaguypKOaaUZEpZpohmynmmkGgKmaaAagq5pohs
+FbOtYrpVzLfKCFdB4yqlXKWcq4R0lbKOiP8KZh0mHeYcoxxoHCUc5RuhG2UbIJtAWbtAWbtAWbsQWbtg
+WbtgWbswWbswWbvYZ+1NOwLWE5zmHQH7zsv+S4HJ8IXJ8AXI8AXJ8AXJ8MU8wxfzDF+ADF/sM3yBMnyB
+MnwhMnxhMnxhMnyxz/DFPsMXIsMXIMMXKMMXKMMXKMMXIsNv/J35ni6AyfCFyfDFPsNv/qn5HhK45g14
+RJCY5i0xzVvbb803Dy0BPWHbb813UJCQZjMhzWYybzaTebPZ+nPzPVQ1/dx8HwPGt/I3Z78p5c0Zrwh5
+czYL+aYVy9fx12OBi/NPcXpz55rroXqhc2vlfhvyt0N3Enfxaa2g9b6Stx5Ap7dGpzdHpzdBZ1Lw4+hM
+ylmgMyloj04m2Mnm8GR7erI5PtmYn2wOkP2K0jdvvyfoXtN0Sc69oMWSnFnFZXye9xWr9PjbE3qed+vd
++l/8BJ7n3eQqO958xc5315bfLTZ+Ps3qHp9+lh/kxNkHdja9/+zjepvev4vTsslmBOzdtKyF7rOSh/Vc
+Yqub1zwW4e0DwGRQ/bPmpz8+/X8Afa8LGD7UAAA=
 `,
 	},
 
 	"/templates/src/network-stack.yml": {
 		name:    "network-stack.yml",
 		local:   "templates/src/network-stack.yml",
-		size:    4278,
-		modtime: 1634116747,
+		size:    10028,
+		modtime: 1760000000,
 		compressed: `
-H4sIAAAAAAAC/8RXS2/yOBTd8ytu0UjZAM2jM1K9ywTaiWbaRoCo1GoWJrht1GBbsdMOqvjvo7xIHAKh
-FPGZDbKPr8+5T+j3+x37cTIlSx5iSW5YtMRyRiIRMIpAM3VD7+vXff1a6wyJ8KOAy+xk5Exg5jlwT+Qn
-i94RyE8GPJ6Hgd/LvkfBB5YERDynRIoeYD9iQqRn9pMYaJ3OHeY8oK8CdQAAJinQYfQleM12kjXzHARf
-4LjDMQJDH6SfS+MPWG8g2UW9BjMH+qV5tQUzajCrGWbWYFfNMKsG+72AdR5iyWOZS5tx311UNOEwJggu
-xuQl0bfZH/3HWSRLXLLu8TKBTuI5dH/7sh8nCE0k9t+T/XU/NdztVPyne2kMSiNq2HJQHqmB1shJsXQo
-O22bnWJHq7I0DmFpHMLSOBFLo4Gl6WU5vJ+mWaT6Xp6FrR8TLQwpTK2DmFoHMbVOxdQqmY6JYHHkk6Ie
-PKc0N11xgiA1MXJMhKoV4UWMk0gGxcViOcEi+jNk/juCi5uALlx6hzk8Kz2kB92Z53R70E0KtAv/KhZc
-KiSmPpkSiqm/QrAgLzgOpQIaUTwPyZCKScxTH4CMYtIM+YsJSfGSiAbQFL/WBCSrD3+TFUpduXWmBqar
-Ojgv+FssySde7fakSyWJKJE5sMmr8LVWjNlSYv9tSajcG6AtdCXvOKEL8UAR7Hm2FgqFprvIVdfvl520
-bJ3p4cj16my1ku7I9bRWDkO2xAFF8MH9zOY9ljv8WzFdgtpfsMOQ+Tipx1RAXjkj1xtUT9aacinL541i
-tTHv6/r1mGWwVpJ3mGeWXP5A/8Ex9d8a0tn+wEGI50EYyNUTo2kjICHxJTyD3oOLWyLtJwGaVqu5A6s2
-l7SrchtTYMdY+WVuME7lBuMIN2zPrSP9cJZAm0cotM6h8GQxtL6lcMxiSURbJqeoaTJ7WjXue6XNjT99
-ZphN1bxLptZaHmtv1UTIgKb9shKF4p+BrmAPGSmlxA2sGoL6ODiJht1v1n57fVdwyXNjuNxSJkb2XlsB
-lURtIZgfpCxa5e0dW9/2fd4Iz07Y+Blh8+yEzabsaU21als9O2XrKMr/BwAA//+lORj/thAAAA==
+H4sIAAAAAAAC/8xZb3PauBN+z6fY8PvN8KKQYlvtXf2OEq7l7powgUlnmuaFMCL21Eg+SW7Kdfrdb2Qb
+/D82GNOSmUyQVqtnn13tIzuDwaAz+jhfkI3nYkn+YHyD5R3hwmHUhJ4+1IaD4ZvB8E2vc0WExR1PhjOT
+8RzuZmO4JvKJ8S8mYPD8petYgOlKfeHOVywJCH9JiQSHAsGWDWwNvgeSwZr5HEafxGWv05lhjjdEEi7M
+DgDAnWeNnRUPv6jPYusRE+aSO/RxP5jCs7AJjKdXt7B0mfVFbSNtogD24cl2LBscAcJzHYVEMiDOoy2B
+/ONjN0IoEn7X2HelCdrwMvh5qb3eT45clz2R1QxLSbji4fPqxefL1K+Xn1cvep1gxTxw/daRIhvLtb9Z
+El4eCw3mVRw2ExKWjhR7DkPA/V2EPZGwcYkQIG1OSEE8xn7sg0PvsOsTE17FY/hbNKYZIfzRV+y4eOm4
+jtx+YpQ0iAInXMG/yhdIBhYncY2oAPOg9QLMegFmFEK+xvIdluQJb8Uh5fPRJtImPOQ3hxVsLADD9WgB
+j6FzWDMOiu9MmTOusrIFYWNOgNGCLMwd+uiSbEEFUQgT7mFGuOK6HxnCQ6czZnTlKKBRTO+xUCaGCRc3
+HO7hYqIKWai/bsk6n7Y+dI0uPPThYu9p5wPBQ9IlMus4Q91o1TWWEdzcukQi+ruY4r3iWRXEiK7gPgku
+9luE2Sjygw73o2Lv3PjS82XceKaruG6i0griuZuN9+OTbx7jMrYLqdgo07m/hO7/v48+zk1zLrH1RY3/
+GASOu53i7pbaJpwMLWdBQw2biDCLK7enzlnUeaNz1FeFBx7h+Uq+7OV2/ZMljp36DKDb76ZHUt9CmwBt
+EqBWx0gvMpqu4X6f235+0W6sG7B6zQLkuxJ8xhcq8IWqfFXktpfPbSpLUeOfhW2hTupSDeScuUtC1GpZ
+1c9eclXj9CWdtZG/VK56nc4tEczn1k7u7mbjrJQELiZj3TSTbWHGmUe4dJI6qT7qQL9V15LMGU/aTKmQ
+mFpkQSim1taEVagXKaMJxUuXXFEx970gSpDcJ8Um75mQFG+IKDBa4McMxJD+v8jWDMjKzaWbVDdNYdTX
+okZcztWUqjsT2XXsIt7g+4+Us5GU2LI3hMpnU5CzThw4j9CVuKEmPLNtJhUpmNNVFHV2fSwYsUIEk7fM
+l0SEbaEcdmC1UNmqRFS4S3ShiFAF3io2e4aUAubKwFwRIR2KVQtLFPbuqjxM2dahMOZhb5YkMAz2fzDK
+tkPQ+hndA4ttCKwdLoLnjfh6HD8Y5GRVK+csNKjk4wP2QodT74b+jX1q2QVHLnuNUu2IuMSScA/DPly8
+I3L0SUCvl+mLyd6RWqAm4D7VUPrwe9Qd4+cOeICH6lpKa0FjSk4aLDp5sMn0h6VWFXFcoyMhmOUE1V9J
+Q7h2D6D8slTrCMRXXW0ynZUjnkxn1WeYbbBDTfjq5Z2Xe46NqivAdZkVsBREpBIuZSaEy6RRfeKSLTYs
+W629JhsjDjxpdTts2SblmY5CObrVxkj3nhN0Fx3zc5Z++V2zmpFSAdBPIQD6LyAA2qE9UWtVAPRWBeDg
+YF+1KQD6TxIAvYkA6LUFYP/Ow0y8C2kiD/pB8nDc9lXioR8rHnqBeOhnEw+9bfHQTyUewVN46t1ZWk70
+3IimXqXlG8nPkhj9QN5KJcY4hcQYtftp4rzs3qicQX70Qzuy3qr8tEvXSYl43aY0Gac4PcdQ9EwHNZrI
+lnGMbKX/WdBEuoxjpetACFXyZRwrX0aBfBkHydcx1VBH2oy60nYMgKr2bZxL9oyasvfLHNwUqAM5LZVE
+dApJRA16PDqDJBqHKoHRqiS2S9dJifitTUlE7Zws1EQSURNJRI0lETWRRHQSSUSNJREdK4moQBJRQ0lE
+J5FEdLwkosaSiM4liaimJP4yBzcF6kBO/xsAso1ssywnAAA=
 `,
 	},
 
@@ -332,8 +529,10 @@ lURtIZgfpCxa5e0dW9/2fd4Iz07Y+Blh8+yEzabsaU21als9O2XrKMr/BwAA//+lORj/thAAAA==
 var _escDirs = map[string][]os.FileInfo{
 
 	"templates/src": {
+		_escData["/templates/src/ecs-schedule.yml"],
 		_escData["/templates/src/ecs-service.yml"],
 		_escData["/templates/src/ecs-stack.yml"],
+		_escData["/templates/src/instance-types.json"],
 		_escData["/templates/src/network-stack.yml"],
 	},
 }
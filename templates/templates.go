@@ -44,6 +44,23 @@ func EcsService() string {
 	return string(b)
 }
 
+func EcsSchedule() string {
+	b, err := readTemplateBytes("/templates/src/ecs-schedule.yml")
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// InstanceTypes returns the catalogue of instance types as JSON
+func InstanceTypes() string {
+	b, err := readTemplateBytes("/templates/src/instance-types.json")
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
 func NetworkStack() string {
 	b, err := readTemplateBytes("/templates/src/network-stack.yml")
 	if err != nil {